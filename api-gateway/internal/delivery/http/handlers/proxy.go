@@ -97,5 +97,25 @@ func (h *ProxyHandler) HandlerFor(service string) http.Handler {
 		}
 	}
 
-	return proxy
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Ответы text/event-stream ReverseProxy сбрасывает клиенту сразу, без буферизации,
+		// поэтому для SSE достаточно снять дедлайны сервера
+		if isEventStream(r) {
+			// SSE-поток живёт дольше таймаутов HTTP-сервера gateway
+			rc := http.NewResponseController(w)
+			if err := rc.SetReadDeadline(time.Time{}); err != nil {
+				log.WithError(err).Warn("Failed to reset read deadline")
+			}
+			if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+				log.WithError(err).Warn("Failed to reset write deadline")
+			}
+		}
+
+		proxy.ServeHTTP(w, r)
+	})
+}
+
+// isEventStream сообщает, что клиент ожидает поток Server-Sent Events.
+func isEventStream(r *http.Request) bool {
+	return strings.Contains(r.Header.Get("Accept"), "text/event-stream")
 }
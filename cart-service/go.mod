@@ -3,15 +3,19 @@ module github.com/Wrestler094/scalable-ecommerce-platform/cart-service
 go 1.23.0
 
 require (
+	github.com/Wrestler094/scalable-ecommerce-platform/gen/go v0.0.0
 	github.com/Wrestler094/scalable-ecommerce-platform/pkg v0.0.0
 	github.com/caarlos0/env/v11 v11.3.1
 	github.com/go-chi/chi/v5 v5.2.2
 	github.com/prometheus/client_golang v1.23.0
 	github.com/redis/go-redis/v9 v9.11.0
+	google.golang.org/grpc v1.64.1
 )
 
 replace github.com/Wrestler094/scalable-ecommerce-platform/pkg => ../pkg
 
+replace github.com/Wrestler094/scalable-ecommerce-platform/gen/go => ../gen/go
+
 require (
 	github.com/beorn7/perks v1.0.1 // indirect
 	github.com/cespare/xxhash/v2 v2.3.0 // indirect
@@ -30,5 +34,6 @@ require (
 	golang.org/x/net v0.42.0 // indirect
 	golang.org/x/sys v0.34.0 // indirect
 	golang.org/x/text v0.27.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20250811230008-5f3141c8851a // indirect
 	google.golang.org/protobuf v1.36.7 // indirect
 )
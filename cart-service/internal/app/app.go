@@ -8,12 +8,14 @@ import (
 	"syscall"
 
 	"github.com/Wrestler094/scalable-ecommerce-platform/pkg/adapters"
+	"github.com/Wrestler094/scalable-ecommerce-platform/pkg/grpcserver"
 	"github.com/Wrestler094/scalable-ecommerce-platform/pkg/healthcheck"
 	"github.com/Wrestler094/scalable-ecommerce-platform/pkg/httpserver"
 	"github.com/Wrestler094/scalable-ecommerce-platform/pkg/logger"
 	"github.com/Wrestler094/scalable-ecommerce-platform/pkg/validator"
 
 	"github.com/Wrestler094/scalable-ecommerce-platform/cart-service/internal/config"
+	grpcHandler "github.com/Wrestler094/scalable-ecommerce-platform/cart-service/internal/delivery/grpc"
 	"github.com/Wrestler094/scalable-ecommerce-platform/cart-service/internal/delivery/http"
 	"github.com/Wrestler094/scalable-ecommerce-platform/cart-service/internal/delivery/http/infra"
 	"github.com/Wrestler094/scalable-ecommerce-platform/cart-service/internal/delivery/http/v1"
@@ -64,6 +66,13 @@ func Run(cfg *config.Config) {
 	// Router
 	router := http.NewRouter(handlers)
 
+	// gRPC Server
+	gRPCServer := grpcserver.New(
+		grpcserver.Port(fmt.Sprintf("%d", cfg.GRPC.Port)),
+	)
+
+	grpcHandler.RegisterServices(gRPCServer.App, cartUseCase, l)
+
 	// HTTP Server
 	httpServer := httpserver.NewServer(
 		httpserver.Port(fmt.Sprintf(":%d", cfg.HTTP.Port)),
@@ -78,6 +87,10 @@ func Run(cfg *config.Config) {
 		l.Fatal("Failed to start server", "error", err)
 	}
 
+	// Start gRPC Server
+	l.Info("gRPC Server running", "port", cfg.GRPC.Port)
+	gRPCServer.Start()
+
 	healthManager.SetReady(true)
 
 	// Waiting signal
@@ -89,6 +102,8 @@ func Run(cfg *config.Config) {
 		l.Info("Received signal", "signal", s)
 	case err = <-httpServer.Notify():
 		l.Error("httpServer.Notify", "error", err)
+	case err = <-gRPCServer.Notify():
+		l.Error("gRPCServer.Notify", "error", err)
 	}
 
 	healthManager.SetReady(false)
@@ -99,4 +114,9 @@ func Run(cfg *config.Config) {
 	if err != nil {
 		l.Error("httpServer.Shutdown", "error", err)
 	}
+
+	err = gRPCServer.Shutdown()
+	if err != nil {
+		l.Error("gRPCServer.Shutdown", "error", err)
+	}
 }
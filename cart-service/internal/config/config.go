@@ -10,6 +10,7 @@ type (
 	Config struct {
 		App     App
 		HTTP    HTTP
+		GRPC    GRPC
 		JWT     JWT
 		Log     Log
 		Redis   Redis
@@ -26,6 +27,10 @@ type (
 		Port int `env:"HTTP_PORT,required"`
 	}
 
+	GRPC struct {
+		Port int `env:"GRPC_PORT,required"`
+	}
+
 	JWT struct{}
 
 	Log struct {
@@ -0,0 +1,21 @@
+package grpc
+
+import (
+	"github.com/Wrestler094/scalable-ecommerce-platform/pkg/logger"
+	"google.golang.org/grpc"
+
+	grpcV1 "github.com/Wrestler094/scalable-ecommerce-platform/cart-service/internal/delivery/grpc/v1"
+	"github.com/Wrestler094/scalable-ecommerce-platform/cart-service/internal/domain"
+	cartv1 "github.com/Wrestler094/scalable-ecommerce-platform/gen/go/cart/v1"
+)
+
+// RegisterServices регистрирует все gRPC обработчики на сервере.
+func RegisterServices(
+	gRPCServer *grpc.Server,
+	cartUC domain.CartUseCase,
+	logger logger.Logger,
+) {
+	// Внутренний API корзины для order-service (оформление заказа из корзины)
+	cartHandler := grpcV1.NewCartHandler(cartUC, logger)
+	cartv1.RegisterCartServiceServer(gRPCServer, cartHandler)
+}
@@ -0,0 +1,74 @@
+package v1
+
+import (
+	"context"
+
+	"github.com/Wrestler094/scalable-ecommerce-platform/pkg/logger"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/Wrestler094/scalable-ecommerce-platform/cart-service/internal/domain"
+	cartv1 "github.com/Wrestler094/scalable-ecommerce-platform/gen/go/cart/v1"
+)
+
+type CartHandler struct {
+	cartv1.UnimplementedCartServiceServer
+	cartUC domain.CartUseCase
+	logger logger.Logger
+}
+
+func NewCartHandler(cartUC domain.CartUseCase, logger logger.Logger) *CartHandler {
+	return &CartHandler{
+		cartUC: cartUC,
+		logger: logger,
+	}
+}
+
+func (h *CartHandler) GetCart(ctx context.Context, req *cartv1.GetCartRequest) (*cartv1.GetCartResponse, error) {
+	if req.GetUserId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	items, err := h.cartUC.GetCart(ctx, req.GetUserId())
+	if err != nil {
+		h.logger.WithError(err).Error("failed to get cart", "user_id", req.GetUserId())
+		return nil, status.Errorf(codes.Internal, "internal server error")
+	}
+
+	return &cartv1.GetCartResponse{Items: convertItemsToProto(items)}, nil
+}
+
+func (h *CartHandler) RemoveCheckedOutItems(ctx context.Context, req *cartv1.RemoveCheckedOutItemsRequest) (*cartv1.RemoveCheckedOutItemsResponse, error) {
+	if req.GetUserId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	items := make([]domain.CartItem, 0, len(req.GetItems()))
+	for _, item := range req.GetItems() {
+		if item.GetQuantity() <= 0 {
+			return nil, status.Error(codes.InvalidArgument, "quantity must be positive")
+		}
+		items = append(items, domain.CartItem{
+			ProductID: item.GetProductId(),
+			Quantity:  int(item.GetQuantity()),
+		})
+	}
+
+	if err := h.cartUC.RemoveCheckedOutItems(ctx, req.GetUserId(), items); err != nil {
+		h.logger.WithError(err).Error("failed to remove checked out items", "user_id", req.GetUserId())
+		return nil, status.Errorf(codes.Internal, "internal server error")
+	}
+
+	return &cartv1.RemoveCheckedOutItemsResponse{}, nil
+}
+
+func convertItemsToProto(items []domain.CartItem) []*cartv1.CartItem {
+	pbItems := make([]*cartv1.CartItem, 0, len(items))
+	for _, item := range items {
+		pbItems = append(pbItems, &cartv1.CartItem{
+			ProductId: item.ProductID,
+			Quantity:  int32(item.Quantity),
+		})
+	}
+	return pbItems
+}
@@ -15,6 +15,8 @@ type CartRepository interface {
 	Update(ctx context.Context, userID, productID int64, quantity int) error
 	Remove(ctx context.Context, userID, productID int64) error
 	Clear(ctx context.Context, userID int64) error
+	// RemoveItems атомарно уменьшает количество указанных товаров и удаляет позиции с нулевым остатком.
+	RemoveItems(ctx context.Context, userID int64, items []CartItem) error
 }
 
 type CartUseCase interface {
@@ -23,4 +25,5 @@ type CartUseCase interface {
 	UpdateItem(ctx context.Context, userID, productID int64, quantity int) error
 	RemoveItem(ctx context.Context, userID, productID int64) error
 	ClearCart(ctx context.Context, userID int64) error
+	RemoveCheckedOutItems(ctx context.Context, userID int64, items []CartItem) error
 }
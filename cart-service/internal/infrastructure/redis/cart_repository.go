@@ -13,6 +13,18 @@ import (
 
 var _ domain.CartRepository = (*redisCartRepo)(nil)
 
+// removeItemsScript уменьшает количество товаров в корзине и удаляет позиции, которые закончились.
+// KEYS[1] — ключ корзины, ARGV — пары product_id, quantity.
+var removeItemsScript = redis.NewScript(`
+for i = 1, #ARGV, 2 do
+	local left = redis.call('HINCRBY', KEYS[1], ARGV[i], -tonumber(ARGV[i + 1]))
+	if left <= 0 then
+		redis.call('HDEL', KEYS[1], ARGV[i])
+	end
+end
+return 1
+`)
+
 type redisCartRepo struct {
 	rdb    *redis.Client
 	prefix string
@@ -75,3 +87,12 @@ func (r *redisCartRepo) Remove(ctx context.Context, userID, productID int64) err
 func (r *redisCartRepo) Clear(ctx context.Context, userID int64) error {
 	return r.rdb.Del(ctx, r.key(userID)).Err()
 }
+
+func (r *redisCartRepo) RemoveItems(ctx context.Context, userID int64, items []domain.CartItem) error {
+	args := make([]any, 0, len(items)*2)
+	for _, item := range items {
+		args = append(args, strconv.FormatInt(item.ProductID, 10), item.Quantity)
+	}
+
+	return removeItemsScript.Run(ctx, r.rdb, []string{r.key(userID)}, args...).Err()
+}
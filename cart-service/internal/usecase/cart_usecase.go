@@ -35,3 +35,12 @@ func (uc *cartUseCase) RemoveItem(ctx context.Context, userID, productID int64)
 func (uc *cartUseCase) ClearCart(ctx context.Context, userID int64) error {
 	return uc.repo.Clear(ctx, userID)
 }
+
+// RemoveCheckedOutItems убирает из корзины позиции, по которым оформлен заказ.
+// Удаляется ровно оформленное количество, поэтому изменения корзины после чтения не теряются.
+func (uc *cartUseCase) RemoveCheckedOutItems(ctx context.Context, userID int64, items []domain.CartItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+	return uc.repo.RemoveItems(ctx, userID, items)
+}
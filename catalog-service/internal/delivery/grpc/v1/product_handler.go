@@ -45,11 +45,14 @@ func convertProductsToProto(products []domain.Product) []*catalogv1.Product {
 	pbProducts := make([]*catalogv1.Product, 0, len(products))
 	for _, p := range products {
 		pbProducts = append(pbProducts, &catalogv1.Product{
-			Id:          p.ID,
-			Name:        p.Name,
-			Description: p.Description,
-			Price:       p.Price,
-			CategoryId:  p.CategoryID,
+			Id:           p.ID,
+			Name:         p.Name,
+			Description:  p.Description,
+			Price:        p.Price,
+			CategoryId:   p.CategoryID,
+			Sku:          p.SKU,
+			TaxClass:     p.TaxClass,
+			CategoryName: p.CategoryName,
 		})
 	}
 	return pbProducts
@@ -85,11 +85,14 @@ func (h *CategoryHandler) GetProductsByCategoryID(w http.ResponseWriter, r *http
 	resp := make(dto.GetProductsByCategoryIDResponse, 0)
 	for _, p := range products {
 		resp = append(resp, dto.Product{
-			ID:          p.ID,
-			Name:        p.Name,
-			Description: p.Description,
-			Price:       p.Price,
-			CategoryID:  p.CategoryID,
+			ID:           p.ID,
+			Name:         p.Name,
+			Description:  p.Description,
+			Price:        p.Price,
+			CategoryID:   p.CategoryID,
+			SKU:          p.SKU,
+			TaxClass:     p.TaxClass,
+			CategoryName: p.CategoryName,
 		})
 	}
 
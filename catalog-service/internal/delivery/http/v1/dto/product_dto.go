@@ -1,11 +1,14 @@
 package dto
 
 type Product struct {
-	ID          int64   `json:"id"`
-	Name        string  `json:"name"`
-	Description string  `json:"description"`
-	Price       float64 `json:"price"`
-	CategoryID  int64   `json:"category_id"`
+	ID           int64   `json:"id"`
+	Name         string  `json:"name"`
+	Description  string  `json:"description"`
+	Price        float64 `json:"price"`
+	CategoryID   int64   `json:"category_id"`
+	SKU          string  `json:"sku,omitempty"`
+	TaxClass     string  `json:"tax_class"`
+	CategoryName string  `json:"category_name,omitempty"`
 }
 
 // ====== CreateProduct ======
@@ -15,6 +18,8 @@ type CreateProductRequest struct {
 	Description string  `json:"description"`
 	Price       float64 `json:"price" validate:"required,gt=0"`
 	CategoryID  int64   `json:"category_id" validate:"required,gt=0"`
+	SKU         string  `json:"sku" validate:"omitempty,max=64"`
+	TaxClass    string  `json:"tax_class" validate:"omitempty,oneof=standard reduced zero exempt"`
 }
 
 type CreateProductResponse struct {
@@ -32,6 +37,8 @@ type UpdateProductRequest struct {
 	Description *string  `json:"description,omitempty"`
 	Price       *float64 `json:"price,omitempty,gt=0"`
 	CategoryID  *int64   `json:"category_id,omitempty,gt=0"`
+	SKU         *string  `json:"sku,omitempty" validate:"omitempty,max=64"`
+	TaxClass    *string  `json:"tax_class,omitempty" validate:"omitempty,oneof=standard reduced zero exempt"`
 }
 
 type UpdateProductResponse Product
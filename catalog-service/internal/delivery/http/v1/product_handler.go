@@ -1,6 +1,7 @@
 package v1
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -9,6 +10,7 @@ import (
 	"github.com/Wrestler094/scalable-ecommerce-platform/pkg/httphelper"
 
 	"github.com/Wrestler094/scalable-ecommerce-platform/catalog-service/internal/delivery/http/v1/dto"
+	"github.com/Wrestler094/scalable-ecommerce-platform/catalog-service/internal/domain"
 	"github.com/Wrestler094/scalable-ecommerce-platform/catalog-service/internal/usecase"
 	usecaseDTO "github.com/Wrestler094/scalable-ecommerce-platform/catalog-service/internal/usecase/dto"
 )
@@ -39,9 +41,15 @@ func (h *ProductHandler) CreateProduct(w http.ResponseWriter, r *http.Request) {
 		Description: req.Description,
 		Price:       req.Price,
 		CategoryID:  req.CategoryID,
+		SKU:         req.SKU,
+		TaxClass:    req.TaxClass,
 	}
 
 	output, err := h.productUC.CreateProduct(r.Context(), input)
+	if errors.Is(err, domain.ErrSKUAlreadyExists) {
+		httphelper.RespondError(w, http.StatusConflict, "product with this sku already exists")
+		return
+	}
 	if err != nil {
 		httphelper.RespondError(w, http.StatusInternalServerError, "failed to create product")
 		return
@@ -89,9 +97,15 @@ func (h *ProductHandler) UpdateProduct(w http.ResponseWriter, r *http.Request) {
 		Description: req.Description,
 		Price:       req.Price,
 		CategoryID:  req.CategoryID,
+		SKU:         req.SKU,
+		TaxClass:    req.TaxClass,
 	}
 
 	output, err := h.productUC.UpdateProduct(r.Context(), id, input)
+	if errors.Is(err, domain.ErrSKUAlreadyExists) {
+		httphelper.RespondError(w, http.StatusConflict, "product with this sku already exists")
+		return
+	}
 	if err != nil {
 		httphelper.RespondError(w, http.StatusInternalServerError, "failed to update product")
 		return
@@ -103,6 +117,8 @@ func (h *ProductHandler) UpdateProduct(w http.ResponseWriter, r *http.Request) {
 		Description: output.Description,
 		Price:       output.Price,
 		CategoryID:  output.CategoryID,
+		SKU:         output.SKU,
+		TaxClass:    output.TaxClass,
 	}
 
 	httphelper.RespondJSON(w, http.StatusOK, response)
@@ -7,4 +7,5 @@ import (
 var (
 	ErrProductNotFound  = errors.New("product not found")
 	ErrCategoryNotFound = errors.New("category not found")
+	ErrSKUAlreadyExists = errors.New("product with this sku already exists")
 )
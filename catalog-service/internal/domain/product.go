@@ -4,12 +4,18 @@ import (
 	"context"
 )
 
+// DefaultTaxClass — налоговая категория товара, если она не указана явно
+const DefaultTaxClass = "standard"
+
 type Product struct {
-	ID          int64
-	Name        string
-	Description string
-	Price       float64
-	CategoryID  int64
+	ID           int64
+	Name         string
+	Description  string
+	Price        float64
+	CategoryID   int64
+	SKU          string
+	TaxClass     string
+	CategoryName string
 }
 
 type ProductRepository interface {
@@ -1,9 +1,16 @@
 package dao
 
+import (
+	"database/sql"
+)
+
 type ProductRow struct {
-	ID          int64   `db:"id"`
-	Name        string  `db:"name"`
-	Description string  `db:"description"`
-	Price       float64 `db:"price"`
-	CategoryID  int64   `db:"category_id"`
+	ID           int64          `db:"id"`
+	Name         string         `db:"name"`
+	Description  string         `db:"description"`
+	Price        float64        `db:"price"`
+	CategoryID   int64          `db:"category_id"`
+	SKU          sql.NullString `db:"sku"`
+	TaxClass     string         `db:"tax_class"`
+	CategoryName sql.NullString `db:"category_name"`
 }
@@ -7,11 +7,20 @@ import (
 	"fmt"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 
 	"github.com/Wrestler094/scalable-ecommerce-platform/catalog-service/internal/domain"
 	"github.com/Wrestler094/scalable-ecommerce-platform/catalog-service/internal/infrastructure/postgres/dao"
 )
 
+const pgErrCodeUniqueViolation = "23505"
+
+// selectProducts — общая выборка товаров вместе с названием категории
+const selectProducts = `
+	SELECT p.id, p.name, p.description, p.price, p.category_id, p.sku, p.tax_class, c.name AS category_name
+	FROM products p
+	LEFT JOIN categories c ON c.id = p.category_id`
+
 type productRepository struct {
 	db *sqlx.DB
 }
@@ -22,18 +31,21 @@ func NewProductRepository(db *sqlx.DB) domain.ProductRepository {
 
 func (r *productRepository) Save(ctx context.Context, p domain.Product) (int64, error) {
 	query := `
-		INSERT INTO products (name, description, price, category_id)
-		VALUES ($1, $2, $3, $4)
+		INSERT INTO products (name, description, price, category_id, sku, tax_class)
+		VALUES ($1, $2, $3, $4, $5, $6)
 		RETURNING id;
 	`
 
 	var id int64
-	err := r.db.QueryRowContext(ctx, query, p.Name, p.Description, p.Price, p.CategoryID).Scan(&id)
+	err := r.db.QueryRowContext(ctx, query, p.Name, p.Description, p.Price, p.CategoryID, nullableString(p.SKU), p.TaxClass).Scan(&id)
+	if isUniqueViolation(err) {
+		return 0, domain.ErrSKUAlreadyExists
+	}
 	return id, err
 }
 
 func (r *productRepository) FindByID(ctx context.Context, id int64) (*domain.Product, error) {
-	query := `SELECT id, name, description, price, category_id FROM products WHERE id = $1`
+	query := selectProducts + ` WHERE p.id = $1`
 
 	var row dao.ProductRow
 	err := r.db.GetContext(ctx, &row, query, id)
@@ -55,7 +67,7 @@ func (r *productRepository) FindByIDs(ctx context.Context, ids []int64) ([]domai
 		return []domain.Product{}, nil
 	}
 
-	query, args, err := sqlx.In("SELECT id, name, description, price, category_id FROM products WHERE id IN (?);", ids)
+	query, args, err := sqlx.In(selectProducts+" WHERE p.id IN (?);", ids)
 	if err != nil {
 		return nil, fmt.Errorf("%s: failed to build query: %w", op, err)
 	}
@@ -76,7 +88,7 @@ func (r *productRepository) FindByIDs(ctx context.Context, ids []int64) ([]domai
 }
 
 func (r *productRepository) FindAll(ctx context.Context) ([]domain.Product, error) {
-	query := `SELECT id, name, description, price, category_id FROM products ORDER BY name ASC`
+	query := selectProducts + ` ORDER BY p.name ASC`
 
 	var rows []dao.ProductRow
 	if err := r.db.SelectContext(ctx, &rows, query); err != nil {
@@ -92,7 +104,7 @@ func (r *productRepository) FindAll(ctx context.Context) ([]domain.Product, erro
 }
 
 func (r *productRepository) FindByCategoryID(ctx context.Context, categoryID int64) ([]domain.Product, error) {
-	query := `SELECT id, name, description, price, category_id FROM products WHERE category_id = $1 ORDER BY name ASC`
+	query := selectProducts + ` WHERE p.category_id = $1 ORDER BY p.name ASC`
 
 	var rows []dao.ProductRow
 	if err := r.db.SelectContext(ctx, &rows, query, categoryID); err != nil {
@@ -108,8 +120,15 @@ func (r *productRepository) FindByCategoryID(ctx context.Context, categoryID int
 }
 
 func (r *productRepository) Update(ctx context.Context, p domain.Product) error {
-	query := `UPDATE products SET name = $1, description = $2, price = $3, category_id = $4 WHERE id = $5`
-	res, err := r.db.ExecContext(ctx, query, p.Name, p.Description, p.Price, p.CategoryID, p.ID)
+	query := `
+		UPDATE products
+		SET name = $1, description = $2, price = $3, category_id = $4, sku = $5, tax_class = $6, updated_at = NOW()
+		WHERE id = $7
+	`
+	res, err := r.db.ExecContext(ctx, query, p.Name, p.Description, p.Price, p.CategoryID, nullableString(p.SKU), p.TaxClass, p.ID)
+	if isUniqueViolation(err) {
+		return domain.ErrSKUAlreadyExists
+	}
 	if err != nil {
 		return err
 	}
@@ -145,10 +164,23 @@ func (r *productRepository) Delete(ctx context.Context, id int64) error {
 
 func mapToDomain(p dao.ProductRow) domain.Product {
 	return domain.Product{
-		ID:          p.ID,
-		Name:        p.Name,
-		Description: p.Description,
-		Price:       p.Price,
-		CategoryID:  p.CategoryID,
+		ID:           p.ID,
+		Name:         p.Name,
+		Description:  p.Description,
+		Price:        p.Price,
+		CategoryID:   p.CategoryID,
+		SKU:          p.SKU.String,
+		TaxClass:     p.TaxClass,
+		CategoryName: p.CategoryName.String,
 	}
 }
+
+// nullableString — пустой SKU храним как NULL, чтобы не нарушать уникальность
+func nullableString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == pgErrCodeUniqueViolation
+}
@@ -6,6 +6,8 @@ type CreateProductInput struct {
 	Description string
 	Price       float64
 	CategoryID  int64
+	SKU         string
+	TaxClass    string
 }
 
 // CreateProductOutput represents output for creating a product
@@ -19,6 +21,8 @@ type UpdateProductInput struct {
 	Description *string
 	Price       *float64
 	CategoryID  *int64
+	SKU         *string
+	TaxClass    *string
 }
 
 // UpdateProductOutput represents output for updating a product
@@ -28,4 +32,6 @@ type UpdateProductOutput struct {
 	Description string
 	Price       float64
 	CategoryID  int64
+	SKU         string
+	TaxClass    string
 }
@@ -33,6 +33,11 @@ func (uc *productUseCase) CreateProduct(ctx context.Context, input dto.CreatePro
 		Description: input.Description,
 		Price:       roundTo2DecimalPlaces(input.Price),
 		CategoryID:  input.CategoryID,
+		SKU:         input.SKU,
+		TaxClass:    input.TaxClass,
+	}
+	if p.TaxClass == "" {
+		p.TaxClass = domain.DefaultTaxClass
 	}
 	id, err := uc.repo.Save(ctx, p)
 	if err != nil {
@@ -67,6 +72,12 @@ func (uc *productUseCase) UpdateProduct(ctx context.Context, id int64, input dto
 	if input.CategoryID != nil {
 		existing.CategoryID = *input.CategoryID
 	}
+	if input.SKU != nil {
+		existing.SKU = *input.SKU
+	}
+	if input.TaxClass != nil {
+		existing.TaxClass = *input.TaxClass
+	}
 
 	if err := uc.repo.Update(ctx, *existing); err != nil {
 		return nil, fmt.Errorf("update product: %w", err)
@@ -78,6 +89,8 @@ func (uc *productUseCase) UpdateProduct(ctx context.Context, id int64, input dto
 		Description: existing.Description,
 		Price:       existing.Price,
 		CategoryID:  existing.CategoryID,
+		SKU:         existing.SKU,
+		TaxClass:    existing.TaxClass,
 	}, nil
 }
 
@@ -0,0 +1,3 @@
+ALTER TABLE products
+    DROP COLUMN IF EXISTS tax_class,
+    DROP COLUMN IF EXISTS sku;
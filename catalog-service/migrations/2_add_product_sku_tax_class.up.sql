@@ -0,0 +1,3 @@
+ALTER TABLE products
+    ADD COLUMN IF NOT EXISTS sku TEXT UNIQUE,
+    ADD COLUMN IF NOT EXISTS tax_class TEXT NOT NULL DEFAULT 'standard';
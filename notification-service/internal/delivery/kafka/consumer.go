@@ -58,32 +58,55 @@ func (c *Consumer) Start(ctx context.Context) {
 			continue
 		}
 
-		var envelope events.Envelope[events.PaymentSuccessfulPayload]
+		var envelope events.Envelope[json.RawMessage]
 		if err := json.Unmarshal(m.Value, &envelope); err != nil {
 			log.WithError(err).Error("Failed to unmarshal envelope", "message_key", m.Key)
 			continue
 		}
 
-		if envelope.EventType != events.EventPaymentSuccessful {
+		evtLog := log.With("event_id", envelope.EventID, "message_key", m.Key)
+
+		var notif domain.Notification
+		switch envelope.EventType {
+		case events.EventPaymentSuccessful:
+			var payload events.PaymentSuccessfulPayload
+			if err := json.Unmarshal(envelope.Payload, &payload); err != nil {
+				evtLog.WithError(err).Error("Failed to unmarshal payment successful payload")
+				continue
+			}
+			notif = domain.Notification{
+				UserID:  payload.UserID,
+				To:      "", // TODO: Implement email retrieval from Kafka or User Service
+				Type:    domain.EmailNotification,
+				Subject: "Ваш платёж прошёл успешно",
+				Message: fmt.Sprintf("Спасибо за оплату заказа %s на сумму %.2f₽", payload.OrderUUID, payload.Amount),
+			}
+
+		case events.EventPaymentRefunded:
+			var payload events.PaymentRefundedPayload
+			if err := json.Unmarshal(envelope.Payload, &payload); err != nil {
+				evtLog.WithError(err).Error("Failed to unmarshal payment refunded payload")
+				continue
+			}
+			notif = domain.Notification{
+				UserID:  payload.UserID,
+				To:      "", // TODO: Implement email retrieval from Kafka or User Service
+				Type:    domain.EmailNotification,
+				Subject: "Возврат средств по заказу",
+				Message: fmt.Sprintf("По заказу %s вам возвращено %.2f₽", payload.OrderUUID, payload.Amount),
+			}
+
+		default:
 			log.Warn("Skipping unsupported event type", "event_type", envelope.EventType)
 			continue
 		}
 
-		payload := envelope.Payload
-		notif := domain.Notification{
-			UserID:  payload.UserID,
-			To:      "", // TODO: Implement email retrieval from Kafka or User Service
-			Type:    domain.EmailNotification,
-			Subject: "Ваш платёж прошёл успешно",
-			Message: fmt.Sprintf("Спасибо за оплату заказа %s на сумму %.2f₽", payload.OrderUUID, payload.Amount),
-		}
-
 		if err := c.usecase.Send(notif); err != nil {
-			log.WithError(err).Error("Failed to send notification", "message_key", m.Key, "event_id", envelope.EventID)
+			evtLog.WithError(err).Error("Failed to send notification")
 			continue
 		}
 
-		log.Info("Notification sent", "order_uuid", payload.OrderUUID)
+		evtLog.Info("Notification sent", "event_type", envelope.EventType)
 	}
 }
 
@@ -9,21 +9,29 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/Wrestler094/scalable-ecommerce-platform/order-service/internal/infrastructure/client/cart"
 	"github.com/Wrestler094/scalable-ecommerce-platform/order-service/internal/infrastructure/client/catalog"
 	"github.com/Wrestler094/scalable-ecommerce-platform/pkg/adapters"
 	"github.com/Wrestler094/scalable-ecommerce-platform/pkg/events"
+	"github.com/Wrestler094/scalable-ecommerce-platform/pkg/grpcserver"
 	"github.com/Wrestler094/scalable-ecommerce-platform/pkg/healthcheck"
 	"github.com/Wrestler094/scalable-ecommerce-platform/pkg/httpserver"
 	"github.com/Wrestler094/scalable-ecommerce-platform/pkg/logger"
+	"github.com/Wrestler094/scalable-ecommerce-platform/pkg/outbox"
 	"github.com/Wrestler094/scalable-ecommerce-platform/pkg/validator"
 
 	"github.com/Wrestler094/scalable-ecommerce-platform/order-service/internal/config"
+	grpcHandler "github.com/Wrestler094/scalable-ecommerce-platform/order-service/internal/delivery/grpc"
 	"github.com/Wrestler094/scalable-ecommerce-platform/order-service/internal/delivery/http"
 	"github.com/Wrestler094/scalable-ecommerce-platform/order-service/internal/delivery/http/infra"
 	"github.com/Wrestler094/scalable-ecommerce-platform/order-service/internal/delivery/http/v1"
 	"github.com/Wrestler094/scalable-ecommerce-platform/order-service/internal/delivery/kafka"
-	paymentmock "github.com/Wrestler094/scalable-ecommerce-platform/order-service/internal/infrastructure/client/payment/mock"
+	"github.com/Wrestler094/scalable-ecommerce-platform/order-service/internal/delivery/worker"
+	"github.com/Wrestler094/scalable-ecommerce-platform/order-service/internal/domain"
+	"github.com/Wrestler094/scalable-ecommerce-platform/order-service/internal/infrastructure/client/payment"
 	"github.com/Wrestler094/scalable-ecommerce-platform/order-service/internal/infrastructure/postgres"
+	"github.com/Wrestler094/scalable-ecommerce-platform/order-service/internal/infrastructure/redis"
+	"github.com/Wrestler094/scalable-ecommerce-platform/order-service/internal/infrastructure/txmanager"
 	"github.com/Wrestler094/scalable-ecommerce-platform/order-service/internal/usecase"
 )
 
@@ -48,33 +56,111 @@ func Run(cfg *config.Config) {
 
 	runLogger.Info("PostgreSQL connected", "host", cfg.PG.Host, "port", cfg.PG.Port, "db", cfg.PG.DBName)
 
+	// Connect Redis
+	rdb, err := redis.NewClient(cfg.Redis.Addr, cfg.Redis.Password, cfg.Redis.DB)
+	if err != nil {
+		runLogger.Fatal("Redis initialization failed", "error", err)
+	}
+	defer rdb.Close()
+
+	runLogger.Info("Redis connected", "addr", cfg.Redis.Addr)
+
 	// Helpers/Deps
 	rawValidator := validator.NewPlaygroundValidator()
 	httpValidator := adapters.NewHttpValidatorAdapter(rawValidator)
 	healthManager := healthcheck.NewManager()
 
 	// Services
-	paymentService := paymentmock.NewMockPaymentService()
+	paymentService, err := payment.NewClient(context.Background(), payment.Config{
+		URL:          cfg.Clients.Payment.URL,
+		Timeout:      cfg.Clients.Payment.Timeout,
+		MaxRetries:   cfg.Clients.Payment.MaxRetries,
+		RetryBackoff: cfg.Clients.Payment.RetryBackoff,
+	})
+	if err != nil {
+		runLogger.WithError(err).Fatal("failed to create payment service client")
+	}
+
 	productProvider, err := catalog.NewClient(context.Background(), cfg.Clients.Catalog)
 	if err != nil {
 		runLogger.WithError(err).Fatal("failed to create catalog service client")
 	}
 
+	cartProvider, err := cart.NewClient(context.Background(), cfg.Clients.Cart)
+	if err != nil {
+		runLogger.WithError(err).Fatal("failed to create cart service client")
+	}
+
 	// Repositories
+	txManager := txmanager.NewTxManager(pg.DB, baseLogger)
 	orderRepo := postgres.NewOrderRepository(pg.DB)
+	outboxStore := outbox.NewStore(pg.DB, txmanager.ExtractTx)
+	inboxRepo := postgres.NewInboxRepository(pg.DB)
+	idempotencyRepo := postgres.NewIdempotencyRepository(pg.DB)
+	returnRepo := postgres.NewReturnRepository(pg.DB)
+	orderEventBus := redis.NewOrderEventBus(rdb.Client, baseLogger)
+
+	// Outbox Relay: несколько реплик захватывают разные события через SKIP LOCKED
+	publisher := outbox.NewKafkaPublisher(cfg.Kafka.Brokers)
+	defer publisher.Close()
+
+	runLogger.Info("Kafka publisher initialized")
+
+	relay := outbox.NewRelay(outboxStore, publisher, baseLogger, outbox.RelayConfig{
+		Interval:    cfg.Outbox.PollInterval,
+		BatchSize:   cfg.Outbox.BatchSize,
+		MaxAttempts: cfg.Outbox.MaxAttempts,
+		BaseBackoff: cfg.Outbox.BaseBackoff,
+		MaxBackoff:  cfg.Outbox.MaxBackoff,
+		Lease:       cfg.Outbox.Lease,
+
+		MetricsNamespace: "order",
+	})
+	relayCtx, relayCancel := context.WithCancel(context.Background())
+	go relay.Run(relayCtx)
+
+	runLogger.Info("Outbox relay initialized")
+
+	shippingRates := domain.ShippingRates{
+		domain.DeliveryMethodCourier: cfg.Shipping.CourierCost,
+		domain.DeliveryMethodPost:    cfg.Shipping.PostCost,
+		domain.DeliveryMethodPickup:  cfg.Shipping.PickupCost,
+	}
 
 	// Use-Cases
-	orderUseCase := usecase.NewOrderUseCase(orderRepo, productProvider, paymentService)
-	paymentUseCase := usecase.NewPaymentUseCase(orderRepo)
+	orderUseCase := usecase.NewOrderUseCase(orderRepo, idempotencyRepo, productProvider, cartProvider, paymentService, outboxStore, txManager, orderEventBus, shippingRates)
+	adminOrderUseCase := usecase.NewAdminOrderUseCase(orderRepo, outboxStore, txManager, orderEventBus)
+	paymentUseCase := usecase.NewPaymentUseCase(orderRepo, inboxRepo, outboxStore, txManager, orderEventBus)
+	expiryUseCase := usecase.NewExpiryUseCase(orderRepo, outboxStore, txManager, orderEventBus)
+	returnUseCase := usecase.NewReturnUseCase(orderRepo, returnRepo)
+	orderEventsUseCase := usecase.NewOrderEventsUseCase(orderRepo, orderEventBus)
+	adminReturnUseCase := usecase.NewAdminReturnUseCase(orderRepo, returnRepo, outboxStore, txManager, orderEventBus)
+
+	// Expiry Worker
+	expiryWorker := worker.NewExpiryWorker(expiryUseCase, baseLogger, cfg.Expiry.TTL, cfg.Expiry.Interval, cfg.Expiry.BatchSize)
+	expiryCtx, expiryCancel := context.WithCancel(context.Background())
+	go expiryWorker.Run(expiryCtx)
+
+	runLogger.Info("Order expiry worker initialized", "ttl", cfg.Expiry.TTL.String())
 
 	// Handlers
 	orderHandler := v1.NewOrderHandler(orderUseCase, httpValidator, baseLogger)
+	adminOrderHandler := v1.NewAdminOrderHandler(adminOrderUseCase, httpValidator, baseLogger)
+	returnHandler := v1.NewReturnHandler(returnUseCase, httpValidator, baseLogger)
+	adminReturnHandler := v1.NewAdminReturnHandler(adminReturnUseCase, httpValidator, baseLogger)
+	orderEventsHandler := v1.NewOrderEventsHandler(orderEventsUseCase, baseLogger)
 	monitoringHandler := infra.NewMonitoringHandler(healthManager)
 
 	// Router
 	router := http.NewRouter(http.Handlers{
 		V1Handlers: v1.Handlers{
-			OrderHandler: orderHandler,
+			OrderHandler:      orderHandler,
+			AdminOrderHandler: adminOrderHandler,
+
+			ReturnHandler:      returnHandler,
+			AdminReturnHandler: adminReturnHandler,
+
+			OrderEventsHandler: orderEventsHandler,
 		},
 		MonitoringHandler: monitoringHandler,
 	})
@@ -91,6 +177,13 @@ func Run(cfg *config.Config) {
 	ctx, cancel := context.WithCancel(context.Background())
 	go consumer.Start(ctx)
 
+	// gRPC Server
+	gRPCServer := grpcserver.New(
+		grpcserver.Port(fmt.Sprintf("%d", cfg.GRPC.Port)),
+	)
+
+	grpcHandler.RegisterServices(gRPCServer.App, orderUseCase, baseLogger)
+
 	// HTTP Server
 	httpServer := httpserver.NewServer(
 		httpserver.Port(fmt.Sprintf(":%d", cfg.HTTP.Port)),
@@ -104,6 +197,9 @@ func Run(cfg *config.Config) {
 		runLogger.WithError(err).Fatal("HTTP server failed to start")
 	}
 
+	runLogger.Info("gRPC server is starting", "port", cfg.GRPC.Port)
+	gRPCServer.Start()
+
 	healthManager.SetReady(true)
 
 	runLogger.Info("Startup complete", logger.LogKeyDurationMS, time.Since(start).String())
@@ -117,6 +213,8 @@ func Run(cfg *config.Config) {
 		runLogger.Info("Received shutdown signal", "signal", sig)
 	case err := <-httpServer.Notify():
 		runLogger.WithError(err).Error("HTTP server reported error")
+	case err := <-gRPCServer.Notify():
+		runLogger.WithError(err).Error("gRPC server reported error")
 	}
 
 	healthManager.SetReady(false)
@@ -128,7 +226,15 @@ func Run(cfg *config.Config) {
 		runLogger.Info("HTTP server gracefully stopped")
 	}
 
+	if err := gRPCServer.Shutdown(); err != nil {
+		runLogger.WithError(err).Error("gRPC server shutdown failed")
+	} else {
+		runLogger.Info("gRPC server gracefully stopped")
+	}
+
 	cancel()
+	relayCancel()
+	expiryCancel()
 	if err := consumer.Close(); err != nil {
 		runLogger.WithError(err).Error("Failed to close consumer")
 	} else {
@@ -2,21 +2,27 @@ package config
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/caarlos0/env/v11"
 )
 
 type (
 	Config struct {
-		App     App
-		HTTP    HTTP
-		JWT     JWT
-		Log     Log
-		PG      PG
-		Kafka   Kafka
-		Metrics Metrics
-		Swagger Swagger
-		Clients Clients
+		App      App
+		HTTP     HTTP
+		GRPC     GRPC
+		JWT      JWT
+		Log      Log
+		PG       PG
+		Redis    Redis
+		Kafka    Kafka
+		Outbox   Outbox
+		Metrics  Metrics
+		Swagger  Swagger
+		Clients  Clients
+		Expiry   Expiry
+		Shipping Shipping
 	}
 
 	App struct {
@@ -28,6 +34,10 @@ type (
 		Port int `env:"HTTP_PORT,required"`
 	}
 
+	GRPC struct {
+		Port int `env:"GRPC_PORT,required"`
+	}
+
 	JWT struct{}
 
 	Log struct {
@@ -42,10 +52,39 @@ type (
 		DBName   string `env:"DB_NAME,required"`
 	}
 
+	Redis struct {
+		Addr     string `env:"REDIS_ADDR,required"`
+		Password string `env:"REDIS_PASSWORD"`
+		DB       int    `env:"REDIS_DB" envDefault:"0"`
+	}
+
 	Kafka struct {
 		Brokers []string `env:"KAFKA_BROKERS,required"`
 	}
 
+	// Outbox — публикация событий из outbox: пакетами, с экспоненциальными повторами
+	Outbox struct {
+		PollInterval time.Duration `env:"OUTBOX_POLL_INTERVAL" envDefault:"5s"`
+		BatchSize    int           `env:"OUTBOX_BATCH_SIZE" envDefault:"100"`
+		MaxAttempts  int           `env:"OUTBOX_MAX_ATTEMPTS" envDefault:"10"`
+		BaseBackoff  time.Duration `env:"OUTBOX_BASE_BACKOFF" envDefault:"1s"`
+		MaxBackoff   time.Duration `env:"OUTBOX_MAX_BACKOFF" envDefault:"5m"`
+		Lease        time.Duration `env:"OUTBOX_LEASE" envDefault:"30s"`
+	}
+
+	Expiry struct {
+		TTL       time.Duration `env:"ORDER_PAYMENT_TTL" envDefault:"30m"`
+		Interval  time.Duration `env:"ORDER_EXPIRY_INTERVAL" envDefault:"1m"`
+		BatchSize int           `env:"ORDER_EXPIRY_BATCH_SIZE" envDefault:"100"`
+	}
+
+	// Shipping — стоимость доставки по способам доставки
+	Shipping struct {
+		CourierCost float64 `env:"SHIPPING_COST_COURIER" envDefault:"300"`
+		PostCost    float64 `env:"SHIPPING_COST_POST" envDefault:"200"`
+		PickupCost  float64 `env:"SHIPPING_COST_PICKUP" envDefault:"0"`
+	}
+
 	Metrics struct {
 		Enabled bool `env:"METRICS_ENABLED" envDefault:"false"`
 	}
@@ -56,8 +95,15 @@ type (
 
 	Clients struct {
 		Catalog string `env:"CATALOG_SERVICE_URL,required"`
-		// TODO: Uncomment when payment client is implemented
-		// Payment string `env:"PAYMENT_SERVICE_URL,required"`
+		Cart    string `env:"CART_SERVICE_URL,required"`
+		Payment PaymentClient
+	}
+
+	PaymentClient struct {
+		URL          string        `env:"PAYMENT_SERVICE_URL,required"`
+		Timeout      time.Duration `env:"PAYMENT_SERVICE_TIMEOUT" envDefault:"3s"`
+		MaxRetries   int           `env:"PAYMENT_SERVICE_MAX_RETRIES" envDefault:"3"`
+		RetryBackoff time.Duration `env:"PAYMENT_SERVICE_RETRY_BACKOFF" envDefault:"200ms"`
 	}
 )
 
@@ -0,0 +1,21 @@
+package grpc
+
+import (
+	"github.com/Wrestler094/scalable-ecommerce-platform/pkg/logger"
+	"google.golang.org/grpc"
+
+	orderv1 "github.com/Wrestler094/scalable-ecommerce-platform/gen/go/order/v1"
+	grpcV1 "github.com/Wrestler094/scalable-ecommerce-platform/order-service/internal/delivery/grpc/v1"
+	"github.com/Wrestler094/scalable-ecommerce-platform/order-service/internal/domain"
+)
+
+// RegisterServices регистрирует все gRPC обработчики на сервере.
+func RegisterServices(
+	gRPCServer *grpc.Server,
+	orderUC domain.OrderUseCase,
+	logger logger.Logger,
+) {
+	// Внутренний API чтения заказов для других сервисов (payment, notification, доставка)
+	orderHandler := grpcV1.NewOrderHandler(orderUC, logger)
+	orderv1.RegisterOrderServiceServer(gRPCServer, orderHandler)
+}
@@ -0,0 +1,165 @@
+package v1
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Wrestler094/scalable-ecommerce-platform/pkg/logger"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	orderv1 "github.com/Wrestler094/scalable-ecommerce-platform/gen/go/order/v1"
+	"github.com/Wrestler094/scalable-ecommerce-platform/order-service/internal/domain"
+)
+
+type OrderHandler struct {
+	orderv1.UnimplementedOrderServiceServer
+	orderUC domain.OrderUseCase
+	logger  logger.Logger
+}
+
+func NewOrderHandler(orderUC domain.OrderUseCase, logger logger.Logger) *OrderHandler {
+	return &OrderHandler{
+		orderUC: orderUC,
+		logger:  logger,
+	}
+}
+
+func (h *OrderHandler) GetOrder(ctx context.Context, req *orderv1.GetOrderRequest) (*orderv1.GetOrderResponse, error) {
+	order, err := h.getOrder(ctx, req.GetOrderUuid())
+	if err != nil {
+		return nil, err
+	}
+
+	return &orderv1.GetOrderResponse{Order: convertOrderToProto(order)}, nil
+}
+
+func (h *OrderHandler) ListOrdersByUser(ctx context.Context, req *orderv1.ListOrdersByUserRequest) (*orderv1.ListOrdersByUserResponse, error) {
+	if req.GetUserId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+	if req.GetLimit() < 0 || req.GetLimit() > domain.MaxOrderListLimit {
+		return nil, status.Errorf(codes.InvalidArgument, "limit must be between 0 and %d", domain.MaxOrderListLimit)
+	}
+
+	filter := domain.OrderListFilter{
+		UserID: req.GetUserId(),
+		Status: domain.OrderStatus(req.GetStatus()),
+		Limit:  int(req.GetLimit()),
+	}
+	if req.GetCursor() != "" {
+		cursor, err := domain.DecodeOrderCursor(req.GetCursor())
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid cursor")
+		}
+		filter.Cursor = &cursor
+	}
+
+	page, err := h.orderUC.ListOrdersByUser(ctx, filter)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidOrderFilter) {
+			return nil, status.Error(codes.InvalidArgument, "invalid order filter")
+		}
+
+		h.logger.WithError(err).Error("failed to list orders", "user_id", req.GetUserId())
+		return nil, status.Errorf(codes.Internal, "internal server error")
+	}
+
+	resp := &orderv1.ListOrdersByUserResponse{
+		Orders: make([]*orderv1.Order, 0, len(page.Orders)),
+	}
+	for _, order := range page.Orders {
+		resp.Orders = append(resp.Orders, convertOrderToProto(order))
+	}
+	if page.NextCursor != nil {
+		resp.NextCursor = page.NextCursor.Encode()
+	}
+
+	return resp, nil
+}
+
+func (h *OrderHandler) GetOrderTotal(ctx context.Context, req *orderv1.GetOrderTotalRequest) (*orderv1.GetOrderTotalResponse, error) {
+	order, err := h.getOrder(ctx, req.GetOrderUuid())
+	if err != nil {
+		return nil, err
+	}
+
+	return &orderv1.GetOrderTotalResponse{
+		OrderUuid:   order.UUID,
+		UserId:      order.UserID,
+		TotalAmount: order.TotalAmount,
+		Status:      string(order.Status),
+	}, nil
+}
+
+func (h *OrderHandler) getOrder(ctx context.Context, orderUUID string) (domain.Order, error) {
+	if _, err := uuid.Parse(orderUUID); err != nil {
+		return domain.Order{}, status.Error(codes.InvalidArgument, "invalid order_uuid")
+	}
+
+	order, err := h.orderUC.GetOrderByUUID(ctx, orderUUID)
+	if err != nil {
+		if errors.Is(err, domain.ErrOrderNotFound) {
+			return domain.Order{}, status.Error(codes.NotFound, "order not found")
+		}
+
+		h.logger.WithError(err).Error("failed to get order", "order_id", orderUUID)
+		return domain.Order{}, status.Errorf(codes.Internal, "internal server error")
+	}
+
+	return order, nil
+}
+
+func convertOrderToProto(o domain.Order) *orderv1.Order {
+	pbOrder := &orderv1.Order{
+		Uuid:                 o.UUID,
+		UserId:               o.UserID,
+		Status:               string(o.Status),
+		Items:                make([]*orderv1.OrderItem, 0, len(o.Items)),
+		TotalAmount:          o.TotalAmount,
+		CreatedAt:            timestamppb.New(o.CreatedAt),
+		CancelReason:         o.CancelReason,
+		PaymentFailureReason: o.PaymentFailureReason,
+	}
+
+	for _, item := range o.Items {
+		pbOrder.Items = append(pbOrder.Items, &orderv1.OrderItem{
+			ProductId:        item.ProductID,
+			Quantity:         int32(item.Quantity),
+			Price:            item.Price,
+			RefundedQuantity: int32(item.RefundedQuantity),
+			RefundedAmount:   item.RefundedAmount,
+			Product: &orderv1.ProductSnapshot{
+				Name:         item.Product.Name,
+				Sku:          item.Product.SKU,
+				CategoryId:   item.Product.CategoryID,
+				CategoryName: item.Product.CategoryName,
+				TaxClass:     item.Product.TaxClass,
+			},
+		})
+	}
+
+	if o.CancelledAt != nil {
+		pbOrder.CancelledAt = timestamppb.New(*o.CancelledAt)
+	}
+
+	if o.Shipping.Method != "" {
+		addr := o.Shipping.Address
+		pbOrder.Shipping = &orderv1.Shipping{
+			Address: &orderv1.ShippingAddress{
+				Recipient:  addr.Recipient,
+				Phone:      addr.Phone,
+				Country:    addr.Country,
+				City:       addr.City,
+				Street:     addr.Street,
+				PostalCode: addr.PostalCode,
+			},
+			Method: string(o.Shipping.Method),
+			Cost:   o.Shipping.Cost,
+		}
+	}
+
+	return pbOrder
+}
@@ -0,0 +1,148 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
+
+	"github.com/Wrestler094/scalable-ecommerce-platform/pkg/authenticator"
+	"github.com/Wrestler094/scalable-ecommerce-platform/pkg/httphelper"
+	"github.com/Wrestler094/scalable-ecommerce-platform/pkg/logger"
+
+	"github.com/Wrestler094/scalable-ecommerce-platform/order-service/internal/delivery/http/v1/dto"
+	"github.com/Wrestler094/scalable-ecommerce-platform/order-service/internal/domain"
+)
+
+type AdminOrderHandler struct {
+	adminUC   domain.AdminOrderUseCase
+	validator httphelper.Validator
+	logger    logger.Logger
+}
+
+func NewAdminOrderHandler(adminUC domain.AdminOrderUseCase, validator httphelper.Validator, logger logger.Logger) *AdminOrderHandler {
+	return &AdminOrderHandler{
+		adminUC:   adminUC,
+		validator: validator,
+		logger:    logger,
+	}
+}
+
+func (h *AdminOrderHandler) ListOrders(w http.ResponseWriter, r *http.Request) {
+	const op = "adminOrderHandler.ListOrders"
+
+	ctx := r.Context()
+
+	filter, err := dto.ParseAdminListOrdersQuery(r.URL.Query())
+	if err != nil {
+		httphelper.RespondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	page, err := h.adminUC.ListOrders(ctx, filter)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidOrderFilter) {
+			httphelper.RespondError(w, http.StatusBadRequest, "invalid order filter")
+			return
+		}
+
+		h.logger.WithOp(op).WithRequestID(middleware.GetReqID(ctx)).WithError(err).Error("Failed to list orders")
+		httphelper.RespondError(w, http.StatusInternalServerError, "failed to list orders")
+		return
+	}
+
+	httphelper.RespondJSON(w, http.StatusOK, dto.FromOrderPage(page))
+}
+
+func (h *AdminOrderHandler) GetOrder(w http.ResponseWriter, r *http.Request) {
+	const op = "adminOrderHandler.GetOrder"
+
+	ctx := r.Context()
+
+	orderID := chi.URLParam(r, "id")
+	if _, err := uuid.Parse(orderID); err != nil {
+		httphelper.RespondError(w, http.StatusBadRequest, "invalid uuid")
+		return
+	}
+
+	order, err := h.adminUC.GetOrder(ctx, orderID)
+	if err != nil {
+		if errors.Is(err, domain.ErrOrderNotFound) {
+			httphelper.RespondError(w, http.StatusNotFound, "order not found")
+			return
+		}
+
+		h.logger.WithOp(op).WithRequestID(middleware.GetReqID(ctx)).WithError(err).Error("Failed to get order", "order_id", orderID)
+		httphelper.RespondError(w, http.StatusInternalServerError, "failed to get order")
+		return
+	}
+
+	resp := dto.GetOrderByIDResponse{
+		Order: dto.FromOrder(order),
+	}
+	httphelper.RespondJSON(w, http.StatusOK, resp)
+}
+
+func (h *AdminOrderHandler) ChangeOrderStatus(w http.ResponseWriter, r *http.Request) {
+	const op = "adminOrderHandler.ChangeOrderStatus"
+
+	ctx := r.Context()
+	adminID, ok := authenticator.UserID(ctx)
+	if !ok {
+		httphelper.RespondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	orderID := chi.URLParam(r, "id")
+	if _, err := uuid.Parse(orderID); err != nil {
+		httphelper.RespondError(w, http.StatusBadRequest, "invalid uuid")
+		return
+	}
+
+	req, err := httphelper.DecodeJSON[dto.ChangeOrderStatusRequest](r, w)
+	if err != nil {
+		httphelper.RespondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if errFields := h.validator.Validate(req); errFields != nil {
+		httphelper.RespondValidationErrors(w, errFields)
+		return
+	}
+
+	cmd := domain.ChangeOrderStatusCommand{
+		OrderUUID: orderID,
+		AdminID:   adminID,
+		Status:    domain.OrderStatus(req.Status),
+		Comment:   req.Comment,
+	}
+
+	order, err := h.adminUC.ChangeOrderStatus(ctx, cmd)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidOrderStatus):
+			httphelper.RespondError(w, http.StatusBadRequest, "unknown order status")
+		case errors.Is(err, domain.ErrStatusCommentRequired):
+			httphelper.RespondError(w, http.StatusBadRequest, "comment is required")
+		case errors.Is(err, domain.ErrOrderNotFound):
+			httphelper.RespondError(w, http.StatusNotFound, "order not found")
+		case errors.Is(err, domain.ErrInvalidStatusTransition),
+			errors.Is(err, domain.ErrOrderStatusConflict):
+			httphelper.RespondError(w, http.StatusConflict, "order cannot be moved to the requested status")
+		default:
+			h.logger.WithOp(op).WithRequestID(middleware.GetReqID(ctx)).WithError(err).Error("Failed to change order status", "order_id", orderID)
+			httphelper.RespondError(w, http.StatusInternalServerError, "failed to change order status")
+		}
+		return
+	}
+
+	h.logger.WithOp(op).WithRequestID(middleware.GetReqID(ctx)).Info("Order status changed by admin",
+		"order_id", orderID, "admin_id", adminID, "status", order.Status)
+
+	resp := dto.ChangeOrderStatusResponse{
+		Order: dto.FromOrder(order),
+	}
+	httphelper.RespondJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,127 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
+
+	"github.com/Wrestler094/scalable-ecommerce-platform/pkg/authenticator"
+	"github.com/Wrestler094/scalable-ecommerce-platform/pkg/httphelper"
+	"github.com/Wrestler094/scalable-ecommerce-platform/pkg/logger"
+
+	"github.com/Wrestler094/scalable-ecommerce-platform/order-service/internal/delivery/http/v1/dto"
+	"github.com/Wrestler094/scalable-ecommerce-platform/order-service/internal/domain"
+)
+
+type AdminReturnHandler struct {
+	adminUC   domain.AdminReturnUseCase
+	validator httphelper.Validator
+	logger    logger.Logger
+}
+
+func NewAdminReturnHandler(adminUC domain.AdminReturnUseCase, validator httphelper.Validator, logger logger.Logger) *AdminReturnHandler {
+	return &AdminReturnHandler{
+		adminUC:   adminUC,
+		validator: validator,
+		logger:    logger,
+	}
+}
+
+func (h *AdminReturnHandler) ListReturns(w http.ResponseWriter, r *http.Request) {
+	const op = "adminReturnHandler.ListReturns"
+
+	ctx := r.Context()
+
+	status := domain.ReturnStatusRequested
+	if s := r.URL.Query().Get("status"); s != "" {
+		status = domain.ReturnStatus(s)
+	}
+
+	returns, err := h.adminUC.ListReturns(ctx, status)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidReturnStatus) {
+			httphelper.RespondError(w, http.StatusBadRequest, "unknown return status")
+			return
+		}
+
+		h.logger.WithOp(op).WithRequestID(middleware.GetReqID(ctx)).WithError(err).Error("Failed to list returns")
+		httphelper.RespondError(w, http.StatusInternalServerError, "failed to list returns")
+		return
+	}
+
+	httphelper.RespondJSON(w, http.StatusOK, dto.FromOrderReturns(returns))
+}
+
+func (h *AdminReturnHandler) ApproveReturn(w http.ResponseWriter, r *http.Request) {
+	h.resolveReturn(w, r, "adminReturnHandler.ApproveReturn", h.adminUC.ApproveReturn)
+}
+
+func (h *AdminReturnHandler) RejectReturn(w http.ResponseWriter, r *http.Request) {
+	h.resolveReturn(w, r, "adminReturnHandler.RejectReturn", h.adminUC.RejectReturn)
+}
+
+func (h *AdminReturnHandler) resolveReturn(
+	w http.ResponseWriter,
+	r *http.Request,
+	op string,
+	resolve func(ctx context.Context, cmd domain.ResolveReturnCommand) (domain.OrderReturn, error),
+) {
+	ctx := r.Context()
+	adminID, ok := authenticator.UserID(ctx)
+	if !ok {
+		httphelper.RespondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	returnID := chi.URLParam(r, "id")
+	if _, err := uuid.Parse(returnID); err != nil {
+		httphelper.RespondError(w, http.StatusBadRequest, "invalid uuid")
+		return
+	}
+
+	req, err := httphelper.DecodeJSON[dto.ResolveReturnRequest](r, w)
+	if err != nil {
+		httphelper.RespondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if errFields := h.validator.Validate(req); errFields != nil {
+		httphelper.RespondValidationErrors(w, errFields)
+		return
+	}
+
+	cmd := domain.ResolveReturnCommand{
+		ReturnUUID: returnID,
+		AdminID:    adminID,
+		Comment:    req.Comment,
+	}
+
+	ret, err := resolve(ctx, cmd)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrReturnNotFound):
+			httphelper.RespondError(w, http.StatusNotFound, "return request not found")
+		case errors.Is(err, domain.ErrStatusCommentRequired):
+			httphelper.RespondError(w, http.StatusBadRequest, "comment is required")
+		case errors.Is(err, domain.ErrReturnAlreadyResolved):
+			httphelper.RespondError(w, http.StatusConflict, "return request is already resolved")
+		case errors.Is(err, domain.ErrInvalidReturnItems),
+			errors.Is(err, domain.ErrInvalidStatusTransition),
+			errors.Is(err, domain.ErrOrderStatusConflict):
+			httphelper.RespondError(w, http.StatusConflict, "return cannot be applied to the order in its current state")
+		default:
+			h.logger.WithOp(op).WithRequestID(middleware.GetReqID(ctx)).WithError(err).Error("Failed to resolve return", "return_id", returnID)
+			httphelper.RespondError(w, http.StatusInternalServerError, "failed to resolve return")
+		}
+		return
+	}
+
+	h.logger.WithOp(op).WithRequestID(middleware.GetReqID(ctx)).Info("Return request resolved by admin",
+		"return_id", returnID, "admin_id", adminID, "status", ret.Status)
+
+	httphelper.RespondJSON(w, http.StatusOK, dto.OrderReturnResponse{Return: dto.FromOrderReturn(ret)})
+}
@@ -0,0 +1,12 @@
+package dto
+
+// ====== ChangeOrderStatus ======
+
+type ChangeOrderStatusRequest struct {
+	Status  string `json:"status" validate:"required"`
+	Comment string `json:"comment" validate:"required,max=1000"`
+}
+
+type ChangeOrderStatusResponse struct {
+	Order Order `json:"order"`
+}
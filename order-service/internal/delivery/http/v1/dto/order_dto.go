@@ -10,15 +10,58 @@ type OrderItem struct {
 	ProductID int64   `json:"product_id"`
 	Quantity  int     `json:"quantity"`
 	Price     float64 `json:"price"`
+
+	RefundedQuantity int     `json:"refunded_quantity,omitempty"`
+	RefundedAmount   float64 `json:"refunded_amount,omitempty"`
+
+	Product *ProductSnapshot `json:"product,omitempty"`
+}
+
+// ProductSnapshot — данные товара на момент оформления заказа
+type ProductSnapshot struct {
+	Name         string `json:"name"`
+	SKU          string `json:"sku,omitempty"`
+	CategoryID   int64  `json:"category_id,omitempty"`
+	CategoryName string `json:"category_name,omitempty"`
+	TaxClass     string `json:"tax_class,omitempty"`
 }
 
 type Order struct {
-	UUID        string      `json:"uuid"`
-	UserID      int64       `json:"user_id"`
-	Status      string      `json:"status"`
-	Items       []OrderItem `json:"items"`
-	TotalAmount float64     `json:"total_amount"`
-	CreatedAt   time.Time   `json:"created_at"`
+	UUID         string      `json:"uuid"`
+	UserID       int64       `json:"user_id"`
+	Status       string      `json:"status"`
+	Items        []OrderItem `json:"items"`
+	TotalAmount  float64     `json:"total_amount"`
+	CreatedAt    time.Time   `json:"created_at"`
+	CancelReason string      `json:"cancel_reason,omitempty"`
+	CancelledAt  *time.Time  `json:"cancelled_at,omitempty"`
+
+	PaymentFailureReason string  `json:"payment_failure_reason,omitempty"`
+	RefundedAmount       float64 `json:"refunded_amount,omitempty"`
+
+	Shipping *Shipping `json:"shipping,omitempty"`
+}
+
+type ShippingAddress struct {
+	Recipient  string `json:"recipient" validate:"required,max=200"`
+	Phone      string `json:"phone" validate:"required,e164"`
+	Country    string `json:"country" validate:"required,iso3166_1_alpha2"`
+	City       string `json:"city" validate:"required,max=100"`
+	Street     string `json:"street" validate:"required,max=300"`
+	PostalCode string `json:"postal_code,omitempty" validate:"omitempty,max=20"`
+}
+
+type Shipping struct {
+	Address ShippingAddress `json:"address"`
+	Method  string          `json:"method"`
+	Cost    float64         `json:"cost"`
+}
+
+// ShippingRequest принимает только адрес целиком. Ссылка на сохранённый адрес пользователя
+// не поддерживается: в user-service нет адресной книги.
+type ShippingRequest struct {
+	Address ShippingAddress `json:"address"`
+	Method  string          `json:"method" validate:"required,oneof=courier post pickup"`
 }
 
 // ====== CreateOrder ======
@@ -29,18 +72,27 @@ type CreateOrderItem struct {
 }
 
 type CreateOrderRequest struct {
-	Items []CreateOrderItem `json:"items"`
+	// Товар указывается в заказе одной строкой: позиции заказа различаются по product_id
+	Items    []CreateOrderItem `json:"items" validate:"unique=ProductID"`
+	Shipping ShippingRequest   `json:"shipping"`
 }
 
 type CreateOrderResponse struct {
 	Order      Order  `json:"order"`
-	PaymentURL string `json:"payment_url"`
+	PaymentURL string `json:"payment_url,omitempty"`
+}
+
+// ====== Checkout ======
+
+type CheckoutRequest struct {
+	Shipping ShippingRequest `json:"shipping"`
 }
 
 // ====== ListOrders ======
 
 type GetOrdersListResponse struct {
-	Orders []Order `json:"orders"`
+	Orders     []Order `json:"orders"`
+	NextCursor string  `json:"next_cursor,omitempty"`
 }
 
 // ====== GetOrderByID ======
@@ -49,6 +101,39 @@ type GetOrderByIDResponse struct {
 	Order Order `json:"order"`
 }
 
+// ====== CancelOrder ======
+
+type CancelOrderRequest struct {
+	Reason string `json:"reason" validate:"required,max=500"`
+}
+
+type CancelOrderResponse struct {
+	Order Order `json:"order"`
+}
+
+// ====== RetryPayment ======
+
+type RetryPaymentResponse struct {
+	Order      Order  `json:"order"`
+	PaymentURL string `json:"payment_url"`
+}
+
+// ====== GetOrderHistory ======
+
+type StatusHistoryEntry struct {
+	FromStatus string    `json:"from_status,omitempty"`
+	ToStatus   string    `json:"to_status"`
+	Actor      string    `json:"actor"`
+	ActorID    int64     `json:"actor_id,omitempty"`
+	Reason     string    `json:"reason,omitempty"`
+	EventID    string    `json:"event_id,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type GetOrderHistoryResponse struct {
+	History []StatusHistoryEntry `json:"history"`
+}
+
 // ====== Convertors ======
 
 func (r CreateOrderRequest) ToDomainItems() []domain.OrderItemInput {
@@ -62,6 +147,30 @@ func (r CreateOrderRequest) ToDomainItems() []domain.OrderItemInput {
 	return items
 }
 
+func (r ShippingRequest) ToDomain() domain.ShippingInput {
+	return domain.ShippingInput{
+		Address: domain.ShippingAddress{
+			Recipient:  r.Address.Recipient,
+			Phone:      r.Address.Phone,
+			Country:    r.Address.Country,
+			City:       r.Address.City,
+			Street:     r.Address.Street,
+			PostalCode: r.Address.PostalCode,
+		},
+		Method: domain.DeliveryMethod(r.Method),
+	}
+}
+
+func FromOrderPage(page domain.OrderPage) GetOrdersListResponse {
+	resp := GetOrdersListResponse{
+		Orders: FromOrders(page.Orders),
+	}
+	if page.NextCursor != nil {
+		resp.NextCursor = page.NextCursor.Encode()
+	}
+	return resp
+}
+
 func FromOrders(orders []domain.Order) []Order {
 	result := make([]Order, 0, len(orders))
 	for _, o := range orders {
@@ -72,12 +181,39 @@ func FromOrders(orders []domain.Order) []Order {
 
 func FromOrder(o domain.Order) Order {
 	return Order{
-		UUID:        o.UUID,
-		UserID:      o.UserID,
-		Status:      o.Status,
-		Items:       fromOrderItems(o.Items),
-		TotalAmount: o.TotalAmount,
-		CreatedAt:   o.CreatedAt,
+		UUID:         o.UUID,
+		UserID:       o.UserID,
+		Status:       string(o.Status),
+		Items:        fromOrderItems(o.Items),
+		TotalAmount:  o.TotalAmount,
+		CreatedAt:    o.CreatedAt,
+		CancelReason: o.CancelReason,
+		CancelledAt:  o.CancelledAt,
+
+		PaymentFailureReason: o.PaymentFailureReason,
+		RefundedAmount:       o.RefundedAmount,
+
+		Shipping: fromShipping(o.Shipping),
+	}
+}
+
+// fromShipping возвращает nil для заказов, созданных без доставки.
+func fromShipping(s domain.Shipping) *Shipping {
+	if s.Method == "" {
+		return nil
+	}
+
+	return &Shipping{
+		Address: ShippingAddress{
+			Recipient:  s.Address.Recipient,
+			Phone:      s.Address.Phone,
+			Country:    s.Address.Country,
+			City:       s.Address.City,
+			Street:     s.Address.Street,
+			PostalCode: s.Address.PostalCode,
+		},
+		Method: string(s.Method),
+		Cost:   s.Cost,
 	}
 }
 
@@ -85,10 +221,47 @@ func fromOrderItems(items []domain.OrderItem) []OrderItem {
 	res := make([]OrderItem, 0, len(items))
 	for _, item := range items {
 		res = append(res, OrderItem{
-			ProductID: item.ProductID,
-			Quantity:  item.Quantity,
-			Price:     item.Price,
+			ProductID:        item.ProductID,
+			Quantity:         item.Quantity,
+			Price:            item.Price,
+			RefundedQuantity: item.RefundedQuantity,
+			RefundedAmount:   item.RefundedAmount,
+			Product:          fromProductSnapshot(item.Product),
 		})
 	}
 	return res
 }
+
+// fromProductSnapshot возвращает nil для заказов, оформленных до появления снимков товара
+func fromProductSnapshot(p domain.ProductSnapshot) *ProductSnapshot {
+	if p == (domain.ProductSnapshot{}) {
+		return nil
+	}
+
+	return &ProductSnapshot{
+		Name:         p.Name,
+		SKU:          p.SKU,
+		CategoryID:   p.CategoryID,
+		CategoryName: p.CategoryName,
+		TaxClass:     p.TaxClass,
+	}
+}
+
+func FromStatusHistory(history []domain.StatusHistoryEntry) GetOrderHistoryResponse {
+	entries := make([]StatusHistoryEntry, 0, len(history))
+	for _, e := range history {
+		entry := StatusHistoryEntry{
+			FromStatus: string(e.FromStatus),
+			ToStatus:   string(e.ToStatus),
+			Actor:      string(e.Actor),
+			ActorID:    e.ActorID,
+			Reason:     e.Reason,
+			CreatedAt:  e.CreatedAt,
+		}
+		if e.EventID != nil {
+			entry.EventID = e.EventID.String()
+		}
+		entries = append(entries, entry)
+	}
+	return GetOrderHistoryResponse{History: entries}
+}
@@ -0,0 +1,41 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/Wrestler094/scalable-ecommerce-platform/order-service/internal/domain"
+)
+
+// SSE-события потока GET /orders/{id}/events
+const (
+	// SSEEventSnapshot — текущий статус заказа в момент подключения
+	SSEEventSnapshot = "snapshot"
+	// SSEEventStatusChanged — переход заказа в новый статус
+	SSEEventStatusChanged = "status_changed"
+)
+
+type OrderStatusEvent struct {
+	OrderUUID  string    `json:"order_uuid"`
+	FromStatus string    `json:"from_status,omitempty"`
+	Status     string    `json:"status"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// ====== Convertors ======
+
+func FromOrderStatusEvent(e domain.OrderStatusEvent) OrderStatusEvent {
+	return OrderStatusEvent{
+		OrderUUID:  e.OrderUUID,
+		FromStatus: string(e.FromStatus),
+		Status:     string(e.Status),
+		OccurredAt: e.OccurredAt,
+	}
+}
+
+func OrderStatusSnapshot(o domain.Order, at time.Time) OrderStatusEvent {
+	return OrderStatusEvent{
+		OrderUUID:  o.UUID,
+		Status:     string(o.Status),
+		OccurredAt: at,
+	}
+}
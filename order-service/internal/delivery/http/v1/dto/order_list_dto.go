@@ -0,0 +1,93 @@
+package dto
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/Wrestler094/scalable-ecommerce-platform/order-service/internal/domain"
+)
+
+// ====== ListOrders query ======
+
+// ParseListOrdersQuery разбирает параметры GET /orders: limit, cursor, status, created_from, created_to.
+// Даты принимаются в формате RFC 3339, created_to не включается в диапазон.
+func ParseListOrdersQuery(q url.Values) (domain.OrderListFilter, error) {
+	filter := domain.OrderListFilter{
+		Status: domain.OrderStatus(q.Get("status")),
+	}
+
+	if v := q.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit <= 0 || limit > domain.MaxOrderListLimit {
+			return domain.OrderListFilter{}, fmt.Errorf("limit must be between 1 and %d", domain.MaxOrderListLimit)
+		}
+		filter.Limit = limit
+	}
+
+	if v := q.Get("cursor"); v != "" {
+		cursor, err := domain.DecodeOrderCursor(v)
+		if err != nil {
+			return domain.OrderListFilter{}, err
+		}
+		filter.Cursor = &cursor
+	}
+
+	for _, p := range []struct {
+		name string
+		dst  **time.Time
+	}{
+		{"created_from", &filter.CreatedFrom},
+		{"created_to", &filter.CreatedTo},
+	} {
+		v := q.Get(p.name)
+		if v == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return domain.OrderListFilter{}, fmt.Errorf("%s must be an RFC 3339 timestamp", p.name)
+		}
+		*p.dst = &t
+	}
+
+	return filter, nil
+}
+
+// ParseAdminListOrdersQuery дополняет ParseListOrdersQuery фильтрами по user_id и диапазону суммы заказа.
+func ParseAdminListOrdersQuery(q url.Values) (domain.OrderListFilter, error) {
+	filter, err := ParseListOrdersQuery(q)
+	if err != nil {
+		return domain.OrderListFilter{}, err
+	}
+
+	if v := q.Get("user_id"); v != "" {
+		userID, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || userID <= 0 {
+			return domain.OrderListFilter{}, errors.New("user_id must be a positive integer")
+		}
+		filter.UserID = userID
+	}
+
+	for _, p := range []struct {
+		name string
+		dst  **float64
+	}{
+		{"min_amount", &filter.MinAmount},
+		{"max_amount", &filter.MaxAmount},
+	} {
+		v := q.Get(p.name)
+		if v == "" {
+			continue
+		}
+		amount, err := strconv.ParseFloat(v, 64)
+		if err != nil || amount < 0 {
+			return domain.OrderListFilter{}, fmt.Errorf("%s must be a non-negative number", p.name)
+		}
+		*p.dst = &amount
+	}
+
+	return filter, nil
+}
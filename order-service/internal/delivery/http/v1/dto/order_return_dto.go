@@ -0,0 +1,102 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/Wrestler094/scalable-ecommerce-platform/order-service/internal/domain"
+)
+
+type ReturnItem struct {
+	ProductID int64   `json:"product_id"`
+	Quantity  int     `json:"quantity"`
+	Amount    float64 `json:"amount"`
+}
+
+type OrderReturn struct {
+	UUID         string       `json:"uuid"`
+	OrderUUID    string       `json:"order_uuid"`
+	UserID       int64        `json:"user_id"`
+	Status       string       `json:"status"`
+	Reason       string       `json:"reason"`
+	Items        []ReturnItem `json:"items"`
+	RefundAmount float64      `json:"refund_amount"`
+	CreatedAt    time.Time    `json:"created_at"`
+
+	ResolvedBy        int64      `json:"resolved_by,omitempty"`
+	ResolutionComment string     `json:"resolution_comment,omitempty"`
+	ResolvedAt        *time.Time `json:"resolved_at,omitempty"`
+}
+
+// ====== RequestReturn ======
+
+type RequestReturnItem struct {
+	ProductID int64 `json:"product_id" validate:"required"`
+	Quantity  int   `json:"quantity" validate:"gte=1"`
+}
+
+type RequestReturnRequest struct {
+	Reason string              `json:"reason" validate:"required,max=1000"`
+	Items  []RequestReturnItem `json:"items" validate:"required,min=1,dive"`
+}
+
+type OrderReturnResponse struct {
+	Return OrderReturn `json:"return"`
+}
+
+// ====== ListReturns ======
+
+type ListReturnsResponse struct {
+	Returns []OrderReturn `json:"returns"`
+}
+
+// ====== ResolveReturn ======
+
+type ResolveReturnRequest struct {
+	Comment string `json:"comment" validate:"max=1000"`
+}
+
+// ====== Convertors ======
+
+func (r RequestReturnRequest) ToDomainItems() []domain.ReturnItemInput {
+	items := make([]domain.ReturnItemInput, len(r.Items))
+	for i, item := range r.Items {
+		items[i] = domain.ReturnItemInput{
+			ProductID: item.ProductID,
+			Quantity:  item.Quantity,
+		}
+	}
+	return items
+}
+
+func FromOrderReturns(returns []domain.OrderReturn) ListReturnsResponse {
+	result := make([]OrderReturn, 0, len(returns))
+	for _, r := range returns {
+		result = append(result, FromOrderReturn(r))
+	}
+	return ListReturnsResponse{Returns: result}
+}
+
+func FromOrderReturn(r domain.OrderReturn) OrderReturn {
+	items := make([]ReturnItem, 0, len(r.Items))
+	for _, item := range r.Items {
+		items = append(items, ReturnItem{
+			ProductID: item.ProductID,
+			Quantity:  item.Quantity,
+			Amount:    item.Amount,
+		})
+	}
+
+	return OrderReturn{
+		UUID:              r.UUID,
+		OrderUUID:         r.OrderUUID,
+		UserID:            r.UserID,
+		Status:            string(r.Status),
+		Reason:            r.Reason,
+		Items:             items,
+		RefundAmount:      r.RefundAmount,
+		CreatedAt:         r.CreatedAt,
+		ResolvedBy:        r.ResolvedBy,
+		ResolutionComment: r.ResolutionComment,
+		ResolvedAt:        r.ResolvedAt,
+	}
+}
@@ -0,0 +1,138 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
+
+	"github.com/Wrestler094/scalable-ecommerce-platform/pkg/authenticator"
+	"github.com/Wrestler094/scalable-ecommerce-platform/pkg/httphelper"
+	"github.com/Wrestler094/scalable-ecommerce-platform/pkg/logger"
+
+	"github.com/Wrestler094/scalable-ecommerce-platform/order-service/internal/delivery/http/v1/dto"
+	"github.com/Wrestler094/scalable-ecommerce-platform/order-service/internal/domain"
+)
+
+// sseHeartbeatInterval — период комментариев-пингов, чтобы прокси не закрывали простаивающее соединение
+const sseHeartbeatInterval = 15 * time.Second
+
+type OrderEventsHandler struct {
+	eventsUC domain.OrderEventsUseCase
+	logger   logger.Logger
+}
+
+func NewOrderEventsHandler(eventsUC domain.OrderEventsUseCase, logger logger.Logger) *OrderEventsHandler {
+	return &OrderEventsHandler{
+		eventsUC: eventsUC,
+		logger:   logger,
+	}
+}
+
+// StreamOrderEvents отдаёт смену статусов заказа через Server-Sent Events.
+// Первым событием приходит текущий статус, дальше — переходы по мере их фиксации.
+func (h *OrderEventsHandler) StreamOrderEvents(w http.ResponseWriter, r *http.Request) {
+	const op = "orderEventsHandler.StreamOrderEvents"
+
+	ctx := r.Context()
+	userID, ok := authenticator.UserID(ctx)
+	if !ok {
+		httphelper.RespondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+	role, _ := authenticator.UserRole(ctx)
+
+	orderID := chi.URLParam(r, "id")
+	if _, err := uuid.Parse(orderID); err != nil {
+		httphelper.RespondError(w, http.StatusBadRequest, "invalid uuid")
+		return
+	}
+
+	log := h.logger.WithOp(op).WithRequestID(middleware.GetReqID(ctx)).With("order_id", orderID)
+
+	order, sub, err := h.eventsUC.SubscribeStatusChanges(ctx, userID, role == authenticator.Admin, orderID)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrOrderNotFound):
+			httphelper.RespondError(w, http.StatusNotFound, "order not found")
+		case errors.Is(err, domain.ErrOrderAccessDenied):
+			httphelper.RespondError(w, http.StatusForbidden, "forbidden")
+		default:
+			log.WithError(err).Error("Failed to subscribe to order events")
+			httphelper.RespondError(w, http.StatusInternalServerError, "failed to subscribe to order events")
+		}
+		return
+	}
+	defer func() {
+		if err := sub.Close(); err != nil {
+			log.WithError(err).Warn("Failed to close order events subscription")
+		}
+	}()
+
+	rc := http.NewResponseController(w)
+
+	// Поток живёт дольше таймаутов HTTP-сервера, поэтому дедлайны соединения снимаются
+	if err := rc.SetReadDeadline(time.Time{}); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		log.WithError(err).Warn("Failed to reset read deadline")
+	}
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		log.WithError(err).Warn("Failed to reset write deadline")
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	// Запрещает буферизацию ответа в nginx и подобных прокси
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	if err := writeSSE(w, rc, dto.SSEEventSnapshot, dto.OrderStatusSnapshot(order, time.Now())); err != nil {
+		log.WithError(err).Debug("Failed to write order snapshot")
+		return
+	}
+
+	heartbeat := time.NewTicker(sseHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-sub.Events():
+			if !ok {
+				// Подписка оборвалась: клиент переподключится и получит актуальный снимок
+				log.Warn("Order events subscription closed")
+				return
+			}
+			if err := writeSSE(w, rc, dto.SSEEventStatusChanged, dto.FromOrderStatusEvent(event)); err != nil {
+				log.WithError(err).Debug("Failed to write order event")
+				return
+			}
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": ping\n\n"); err != nil {
+				return
+			}
+			if err := rc.Flush(); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func writeSSE(w http.ResponseWriter, rc *http.ResponseController, event string, data any) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, payload); err != nil {
+		return fmt.Errorf("failed to write event: %w", err)
+	}
+
+	return rc.Flush()
+}
@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
 	"github.com/google/uuid"
 
 	"github.com/Wrestler094/scalable-ecommerce-platform/pkg/authenticator"
@@ -15,6 +16,11 @@ import (
 	"github.com/Wrestler094/scalable-ecommerce-platform/order-service/internal/domain"
 )
 
+const (
+	IdempotencyKeyHeader    = "Idempotency-Key"
+	maxIdempotencyKeyLength = 255
+)
+
 type OrderHandler struct {
 	orderUC   domain.OrderUseCase
 	validator httphelper.Validator
@@ -50,14 +56,92 @@ func (h *OrderHandler) CreateOrder(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	items := req.ToDomainItems()
-	order, paymentURL, err := h.orderUC.CreateOrder(ctx, userID, items)
+	idempotencyKey := r.Header.Get(IdempotencyKeyHeader)
+	if len(idempotencyKey) > maxIdempotencyKeyLength {
+		httphelper.RespondError(w, http.StatusBadRequest, "idempotency key is too long")
+		return
+	}
+
+	cmd := domain.CreateOrderCommand{
+		UserID:         userID,
+		Items:          req.ToDomainItems(),
+		Shipping:       req.Shipping.ToDomain(),
+		IdempotencyKey: idempotencyKey,
+	}
+
+	order, paymentURL, err := h.orderUC.CreateOrder(ctx, cmd)
+	if err != nil {
+		log := h.logger.WithOp(op).WithRequestID(middleware.GetReqID(ctx)).WithError(err)
+
+		switch {
+		case errors.Is(err, domain.ErrIdempotencyKeyReused):
+			httphelper.RespondError(w, http.StatusUnprocessableEntity, "idempotency key was already used with a different request")
+			return
+		case errors.Is(err, domain.ErrIdempotencyRequestInProgress):
+			httphelper.RespondError(w, http.StatusConflict, "request with this idempotency key is still in progress")
+			return
+		case errors.Is(err, domain.ErrUnsupportedDeliveryMethod):
+			httphelper.RespondError(w, http.StatusUnprocessableEntity, "delivery method is not available")
+			return
+		case order.UUID == "":
+			log.Error("Failed to create order")
+			httphelper.RespondError(w, http.StatusInternalServerError, "failed to create order")
+			return
+		}
+
+		// Заказ создан, но платёж не инициирован — клиент может повторить оплату через /orders/{id}/pay
+		log.Warn("Order created with errors", "order_id", order.UUID)
+	}
+
+	resp := dto.CreateOrderResponse{
+		Order:      dto.FromOrder(order),
+		PaymentURL: paymentURL,
+	}
+	httphelper.RespondJSON(w, http.StatusCreated, resp)
+}
+
+func (h *OrderHandler) Checkout(w http.ResponseWriter, r *http.Request) {
+	const op = "orderHandler.Checkout"
+
+	ctx := r.Context()
+	userID, ok := authenticator.UserID(ctx)
+	if !ok {
+		httphelper.RespondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	req, err := httphelper.DecodeJSON[dto.CheckoutRequest](r, w)
 	if err != nil {
-		h.logger.WithOp(op).WithError(err).Error("Failed to create order")
-		httphelper.RespondError(w, http.StatusInternalServerError, "failed to create order")
+		httphelper.RespondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if errFields := h.validator.Validate(req); errFields != nil {
+		httphelper.RespondValidationErrors(w, errFields)
 		return
 	}
 
+	order, paymentURL, err := h.orderUC.Checkout(ctx, userID, req.Shipping.ToDomain())
+	if err != nil {
+		log := h.logger.WithOp(op).WithRequestID(middleware.GetReqID(ctx)).WithError(err)
+
+		switch {
+		case errors.Is(err, domain.ErrCartEmpty):
+			httphelper.RespondError(w, http.StatusUnprocessableEntity, "cart is empty")
+			return
+		case errors.Is(err, domain.ErrUnsupportedDeliveryMethod):
+			httphelper.RespondError(w, http.StatusUnprocessableEntity, "delivery method is not available")
+			return
+		case order.UUID == "":
+			log.Error("Failed to checkout cart")
+			httphelper.RespondError(w, http.StatusInternalServerError, "failed to checkout cart")
+			return
+		}
+
+		// Заказ сохранён: платёж можно повторить через /orders/{id}/pay, а корзину пользователь очистит сам
+		log.Warn("Order created from cart with errors", "order_id", order.UUID)
+	}
+
 	resp := dto.CreateOrderResponse{
 		Order:      dto.FromOrder(order),
 		PaymentURL: paymentURL,
@@ -75,18 +159,26 @@ func (h *OrderHandler) GetOrdersList(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	orders, err := h.orderUC.ListOrdersByUser(ctx, userID)
+	filter, err := dto.ParseListOrdersQuery(r.URL.Query())
 	if err != nil {
-		h.logger.WithOp(op).WithError(err).Error("Failed to get orders list")
-		httphelper.RespondError(w, http.StatusInternalServerError, "failed to get orders list")
+		httphelper.RespondError(w, http.StatusBadRequest, err.Error())
 		return
 	}
+	filter.UserID = userID
+
+	page, err := h.orderUC.ListOrdersByUser(ctx, filter)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidOrderFilter) {
+			httphelper.RespondError(w, http.StatusBadRequest, "invalid order filter")
+			return
+		}
 
-	resp := dto.GetOrdersListResponse{
-		Orders: dto.FromOrders(orders),
+		h.logger.WithOp(op).WithError(err).Error("Failed to get orders list")
+		httphelper.RespondError(w, http.StatusInternalServerError, "failed to get orders list")
+		return
 	}
 
-	httphelper.RespondJSON(w, http.StatusOK, resp)
+	httphelper.RespondJSON(w, http.StatusOK, dto.FromOrderPage(page))
 }
 
 func (h *OrderHandler) GetOrderByID(w http.ResponseWriter, r *http.Request) {
@@ -127,3 +219,148 @@ func (h *OrderHandler) GetOrderByID(w http.ResponseWriter, r *http.Request) {
 	}
 	httphelper.RespondJSON(w, http.StatusOK, resp)
 }
+
+func (h *OrderHandler) GetOrderHistory(w http.ResponseWriter, r *http.Request) {
+	const op = "orderHandler.GetOrderHistory"
+
+	ctx := r.Context()
+	userID, ok := authenticator.UserID(ctx)
+	if !ok {
+		httphelper.RespondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+	role, _ := authenticator.UserRole(ctx)
+
+	orderID := chi.URLParam(r, "id")
+	if _, err := uuid.Parse(orderID); err != nil {
+		httphelper.RespondError(w, http.StatusBadRequest, "invalid uuid")
+		return
+	}
+
+	history, err := h.orderUC.GetOrderHistory(ctx, userID, role == authenticator.Admin, orderID)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrOrderNotFound):
+			httphelper.RespondError(w, http.StatusNotFound, "order not found")
+		case errors.Is(err, domain.ErrOrderAccessDenied):
+			httphelper.RespondError(w, http.StatusForbidden, "forbidden")
+		default:
+			h.logger.WithOp(op).WithRequestID(middleware.GetReqID(ctx)).WithError(err).Error("Failed to get order history", "order_id", orderID)
+			httphelper.RespondError(w, http.StatusInternalServerError, "failed to get order history")
+		}
+		return
+	}
+
+	httphelper.RespondJSON(w, http.StatusOK, dto.FromStatusHistory(history))
+}
+
+func (h *OrderHandler) CancelOrder(w http.ResponseWriter, r *http.Request) {
+	const op = "orderHandler.CancelOrder"
+
+	ctx := r.Context()
+	userID, ok := authenticator.UserID(ctx)
+	if !ok {
+		httphelper.RespondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+	role, _ := authenticator.UserRole(ctx)
+
+	orderID := chi.URLParam(r, "id")
+	if _, err := uuid.Parse(orderID); err != nil {
+		httphelper.RespondError(w, http.StatusBadRequest, "invalid uuid")
+		return
+	}
+
+	req, err := httphelper.DecodeJSON[dto.CancelOrderRequest](r, w)
+	if err != nil {
+		httphelper.RespondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if errFields := h.validator.Validate(req); errFields != nil {
+		httphelper.RespondValidationErrors(w, errFields)
+		return
+	}
+
+	cmd := domain.CancelOrderCommand{
+		OrderUUID: orderID,
+		UserID:    userID,
+		IsAdmin:   role == authenticator.Admin,
+		Reason:    req.Reason,
+	}
+
+	order, err := h.orderUC.CancelOrder(ctx, cmd)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrOrderNotFound):
+			httphelper.RespondError(w, http.StatusNotFound, "order not found")
+			return
+
+		case errors.Is(err, domain.ErrOrderAccessDenied):
+			httphelper.RespondError(w, http.StatusForbidden, "forbidden")
+			return
+
+		case errors.Is(err, domain.ErrOrderCancelNotAllowed):
+			httphelper.RespondError(w, http.StatusConflict, "order is already paid and can only be cancelled by support")
+			return
+
+		case errors.Is(err, domain.ErrInvalidStatusTransition),
+			errors.Is(err, domain.ErrOrderStatusConflict):
+			httphelper.RespondError(w, http.StatusConflict, "order cannot be cancelled in its current status")
+			return
+
+		default:
+			h.logger.WithOp(op).WithRequestID(middleware.GetReqID(ctx)).WithError(err).Error("Failed to cancel order", "order_id", orderID)
+			httphelper.RespondError(w, http.StatusInternalServerError, "failed to cancel order")
+			return
+		}
+	}
+
+	resp := dto.CancelOrderResponse{
+		Order: dto.FromOrder(order),
+	}
+	httphelper.RespondJSON(w, http.StatusOK, resp)
+}
+
+func (h *OrderHandler) RetryPayment(w http.ResponseWriter, r *http.Request) {
+	const op = "orderHandler.RetryPayment"
+
+	ctx := r.Context()
+	userID, ok := authenticator.UserID(ctx)
+	if !ok {
+		httphelper.RespondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	orderID := chi.URLParam(r, "id")
+	if _, err := uuid.Parse(orderID); err != nil {
+		httphelper.RespondError(w, http.StatusBadRequest, "invalid uuid")
+		return
+	}
+
+	order, paymentURL, err := h.orderUC.RetryPayment(ctx, userID, orderID)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrOrderNotFound):
+			httphelper.RespondError(w, http.StatusNotFound, "order not found")
+		case errors.Is(err, domain.ErrOrderAccessDenied):
+			httphelper.RespondError(w, http.StatusForbidden, "forbidden")
+		case errors.Is(err, domain.ErrPaymentRetryNotAllowed),
+			errors.Is(err, domain.ErrInvalidStatusTransition),
+			errors.Is(err, domain.ErrOrderStatusConflict):
+			httphelper.RespondError(w, http.StatusConflict, "order payment cannot be retried in its current status")
+		case errors.Is(err, domain.ErrPaymentServiceUnavailable):
+			httphelper.RespondError(w, http.StatusServiceUnavailable, "payment service is temporarily unavailable")
+		default:
+			h.logger.WithOp(op).WithRequestID(middleware.GetReqID(ctx)).WithError(err).Error("Failed to retry payment", "order_id", orderID)
+			httphelper.RespondError(w, http.StatusInternalServerError, "failed to retry payment")
+		}
+		return
+	}
+
+	resp := dto.RetryPaymentResponse{
+		Order:      dto.FromOrder(order),
+		PaymentURL: paymentURL,
+	}
+	httphelper.RespondJSON(w, http.StatusOK, resp)
+}
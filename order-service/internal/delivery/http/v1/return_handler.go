@@ -0,0 +1,122 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
+
+	"github.com/Wrestler094/scalable-ecommerce-platform/pkg/authenticator"
+	"github.com/Wrestler094/scalable-ecommerce-platform/pkg/httphelper"
+	"github.com/Wrestler094/scalable-ecommerce-platform/pkg/logger"
+
+	"github.com/Wrestler094/scalable-ecommerce-platform/order-service/internal/delivery/http/v1/dto"
+	"github.com/Wrestler094/scalable-ecommerce-platform/order-service/internal/domain"
+)
+
+type ReturnHandler struct {
+	returnUC  domain.ReturnUseCase
+	validator httphelper.Validator
+	logger    logger.Logger
+}
+
+func NewReturnHandler(returnUC domain.ReturnUseCase, validator httphelper.Validator, logger logger.Logger) *ReturnHandler {
+	return &ReturnHandler{
+		returnUC:  returnUC,
+		validator: validator,
+		logger:    logger,
+	}
+}
+
+func (h *ReturnHandler) RequestReturn(w http.ResponseWriter, r *http.Request) {
+	const op = "returnHandler.RequestReturn"
+
+	ctx := r.Context()
+	userID, ok := authenticator.UserID(ctx)
+	if !ok {
+		httphelper.RespondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	orderID := chi.URLParam(r, "id")
+	if _, err := uuid.Parse(orderID); err != nil {
+		httphelper.RespondError(w, http.StatusBadRequest, "invalid uuid")
+		return
+	}
+
+	req, err := httphelper.DecodeJSON[dto.RequestReturnRequest](r, w)
+	if err != nil {
+		httphelper.RespondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if errFields := h.validator.Validate(req); errFields != nil {
+		httphelper.RespondValidationErrors(w, errFields)
+		return
+	}
+
+	cmd := domain.RequestReturnCommand{
+		OrderUUID: orderID,
+		UserID:    userID,
+		Reason:    req.Reason,
+		Items:     req.ToDomainItems(),
+	}
+
+	ret, err := h.returnUC.RequestReturn(ctx, cmd)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrOrderNotFound):
+			httphelper.RespondError(w, http.StatusNotFound, "order not found")
+		case errors.Is(err, domain.ErrOrderAccessDenied):
+			httphelper.RespondError(w, http.StatusForbidden, "forbidden")
+		case errors.Is(err, domain.ErrReturnNotAllowed):
+			httphelper.RespondError(w, http.StatusConflict, "order is not eligible for return")
+		case errors.Is(err, domain.ErrReturnAlreadyRequested):
+			httphelper.RespondError(w, http.StatusConflict, "order already has a pending return request")
+		case errors.Is(err, domain.ErrInvalidReturnItems):
+			httphelper.RespondError(w, http.StatusUnprocessableEntity, "invalid return items")
+		default:
+			h.logger.WithOp(op).WithRequestID(middleware.GetReqID(ctx)).WithError(err).Error("Failed to request return", "order_id", orderID)
+			httphelper.RespondError(w, http.StatusInternalServerError, "failed to request return")
+		}
+		return
+	}
+
+	httphelper.RespondJSON(w, http.StatusCreated, dto.OrderReturnResponse{Return: dto.FromOrderReturn(ret)})
+}
+
+func (h *ReturnHandler) ListOrderReturns(w http.ResponseWriter, r *http.Request) {
+	const op = "returnHandler.ListOrderReturns"
+
+	ctx := r.Context()
+	userID, ok := authenticator.UserID(ctx)
+	if !ok {
+		httphelper.RespondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+	role, _ := authenticator.UserRole(ctx)
+
+	orderID := chi.URLParam(r, "id")
+	if _, err := uuid.Parse(orderID); err != nil {
+		httphelper.RespondError(w, http.StatusBadRequest, "invalid uuid")
+		return
+	}
+
+	returns, err := h.returnUC.ListOrderReturns(ctx, userID, role == authenticator.Admin, orderID)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrOrderNotFound):
+			httphelper.RespondError(w, http.StatusNotFound, "order not found")
+		case errors.Is(err, domain.ErrOrderAccessDenied):
+			httphelper.RespondError(w, http.StatusForbidden, "forbidden")
+		default:
+			h.logger.WithOp(op).WithRequestID(middleware.GetReqID(ctx)).WithError(err).Error("Failed to list order returns", "order_id", orderID)
+			httphelper.RespondError(w, http.StatusInternalServerError, "failed to list order returns")
+		}
+		return
+	}
+
+	httphelper.RespondJSON(w, http.StatusOK, dto.FromOrderReturns(returns))
+}
@@ -9,7 +9,13 @@ import (
 )
 
 type Handlers struct {
-	OrderHandler *OrderHandler
+	OrderHandler      *OrderHandler
+	AdminOrderHandler *AdminOrderHandler
+
+	ReturnHandler      *ReturnHandler
+	AdminReturnHandler *AdminReturnHandler
+
+	OrderEventsHandler *OrderEventsHandler
 }
 
 func NewV1Router(h Handlers) http.Handler {
@@ -21,7 +27,31 @@ func NewV1Router(h Handlers) http.Handler {
 
 		r.Get("/", h.OrderHandler.GetOrdersList)
 		r.Post("/", h.OrderHandler.CreateOrder)
+		r.Post("/checkout", h.OrderHandler.Checkout)
 		r.Get("/{id}", h.OrderHandler.GetOrderByID)
+		r.Get("/{id}/history", h.OrderHandler.GetOrderHistory)
+		r.Get("/{id}/events", h.OrderEventsHandler.StreamOrderEvents)
+		r.Post("/{id}/cancel", h.OrderHandler.CancelOrder)
+		r.Post("/{id}/pay", h.OrderHandler.RetryPayment)
+		r.Get("/{id}/returns", h.ReturnHandler.ListOrderReturns)
+		r.Post("/{id}/returns", h.ReturnHandler.RequestReturn)
+	})
+
+	// Admin only endpoints
+	r.Route("/admin/orders", func(r chi.Router) {
+		r.Use(authenticator.RequireAdmin())
+
+		r.Get("/", h.AdminOrderHandler.ListOrders)
+		r.Get("/{id}", h.AdminOrderHandler.GetOrder)
+		r.Post("/{id}/status", h.AdminOrderHandler.ChangeOrderStatus)
+	})
+
+	r.Route("/admin/returns", func(r chi.Router) {
+		r.Use(authenticator.RequireAdmin())
+
+		r.Get("/", h.AdminReturnHandler.ListReturns)
+		r.Post("/{id}/approve", h.AdminReturnHandler.ApproveReturn)
+		r.Post("/{id}/reject", h.AdminReturnHandler.RejectReturn)
 	})
 
 	return r
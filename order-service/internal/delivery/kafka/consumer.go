@@ -3,8 +3,10 @@ package kafka
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 
+	"github.com/google/uuid"
 	"github.com/segmentio/kafka-go"
 
 	"github.com/Wrestler094/scalable-ecommerce-platform/pkg/events"
@@ -59,23 +61,97 @@ func (c *Consumer) Start(ctx context.Context) {
 			continue
 		}
 
-		var envelope events.Envelope[dto.PaymentPayload]
+		var envelope events.Envelope[json.RawMessage]
 		if err := json.Unmarshal(m.Value, &envelope); err != nil {
 			log.WithError(err).Error("Failed to unmarshal envelope", "message_key", m.Key)
 			continue
 		}
 
-		if envelope.EventType != events.EventPaymentSuccessful {
-			log.Warn("Skipping unsupported event type", "type", envelope.EventType)
-			continue
+		evtLog := log.With("event_id", envelope.EventID, "event_type", envelope.EventType)
+
+		switch envelope.EventType {
+		case events.EventPaymentSuccessful:
+			c.handlePaymentSuccessful(ctx, evtLog, envelope.EventID, envelope.Payload)
+		case events.EventPaymentFailed:
+			c.handlePaymentFailed(ctx, evtLog, envelope.EventID, envelope.Payload)
+		case events.EventPaymentRefunded:
+			c.handlePaymentRefunded(ctx, evtLog, envelope.EventID, envelope.Payload)
+		default:
+			evtLog.Warn("Skipping unsupported event type")
 		}
+	}
+}
 
-		if err := c.usecase.MarkOrderAsPaid(ctx, envelope.Payload.OrderUUID); err != nil {
-			log.WithError(err).Error("Failed to mark order as paid", "message_key", m.Key, "event_id", envelope.EventID)
-			continue
-		}
+func (c *Consumer) handlePaymentSuccessful(ctx context.Context, log logger.Logger, eventID uuid.UUID, raw json.RawMessage) {
+	var payload dto.PaymentPayload
+	if err := json.Unmarshal(raw, &payload); err != nil {
+		log.WithError(err).Error("Failed to unmarshal payment payload")
+		return
+	}
+
+	log = log.With("order_id", payload.OrderUUID)
+
+	if err := c.usecase.MarkOrderAsPaid(ctx, eventID, payload.OrderUUID); err != nil {
+		logUseCaseError(log, err, "Failed to mark order as paid")
+		return
+	}
+
+	log.Info("Order marked as paid")
+}
+
+func (c *Consumer) handlePaymentFailed(ctx context.Context, log logger.Logger, eventID uuid.UUID, raw json.RawMessage) {
+	var payload dto.PaymentFailedPayload
+	if err := json.Unmarshal(raw, &payload); err != nil {
+		log.WithError(err).Error("Failed to unmarshal payment failed payload")
+		return
+	}
+
+	log = log.With("order_id", payload.OrderUUID)
+
+	if err := c.usecase.MarkOrderPaymentFailed(ctx, eventID, payload.OrderUUID, payload.Reason); err != nil {
+		logUseCaseError(log, err, "Failed to mark order payment as failed")
+		return
+	}
+
+	log.Info("Order payment marked as failed", "reason", payload.Reason)
+}
+
+func (c *Consumer) handlePaymentRefunded(ctx context.Context, log logger.Logger, eventID uuid.UUID, raw json.RawMessage) {
+	var payload dto.PaymentRefundedPayload
+	if err := json.Unmarshal(raw, &payload); err != nil {
+		log.WithError(err).Error("Failed to unmarshal payment refunded payload")
+		return
+	}
+
+	log = log.With("order_id", payload.OrderUUID)
+
+	// Статус заказа по заявке на возврат меняется уже при её одобрении
+	if payload.ReturnUUID != "" {
+		return
+	}
+
+	if err := c.usecase.MarkOrderRefunded(ctx, eventID, payload.OrderUUID, payload.TotalRefunded, payload.FullyRefunded); err != nil {
+		logUseCaseError(log, err, "Failed to mark order as refunded")
+		return
+	}
+
+	log.Info("Order refund recorded", "amount", payload.Amount, "total_refunded", payload.TotalRefunded, "fully_refunded", payload.FullyRefunded)
+}
 
-		log.Info("Order marked as paid", "order_id", envelope.Payload.OrderUUID, "event_id", envelope.EventID)
+// logUseCaseError логирует ошибку обработки события.
+// Ожидаемые доменные отказы логируются как предупреждения: событие считается обработанным.
+func logUseCaseError(log logger.Logger, err error, msg string) {
+	switch {
+	case errors.Is(err, domain.ErrEventAlreadyProcessed):
+		// Повторная доставка того же события: изменения уже применены
+		log.Info("Skipping duplicate event")
+	case errors.Is(err, domain.ErrInvalidStatusTransition):
+		// Поздние и повторные события (например, оплата отменённого заказа) не должны менять статус
+		log.WithError(err).Warn("Skipping event: order status transition is not allowed")
+	case errors.Is(err, domain.ErrOrderNotFound):
+		log.WithError(err).Warn("Skipping event: order not found")
+	default:
+		log.WithError(err).Error(msg)
 	}
 }
 
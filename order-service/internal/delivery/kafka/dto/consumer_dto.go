@@ -5,3 +5,18 @@ type PaymentPayload struct {
 	UserID    int64   `json:"user_id"`
 	Amount    float64 `json:"amount"`
 }
+
+type PaymentFailedPayload struct {
+	OrderUUID string  `json:"order_uuid"`
+	UserID    int64   `json:"user_id"`
+	Amount    float64 `json:"amount"`
+	Reason    string  `json:"reason"`
+}
+
+type PaymentRefundedPayload struct {
+	OrderUUID     string  `json:"order_uuid"`
+	Amount        float64 `json:"amount"`
+	TotalRefunded float64 `json:"total_refunded"`
+	FullyRefunded bool    `json:"fully_refunded"`
+	ReturnUUID    string  `json:"return_uuid"`
+}
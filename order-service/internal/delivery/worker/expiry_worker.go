@@ -0,0 +1,73 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/Wrestler094/scalable-ecommerce-platform/pkg/logger"
+
+	"github.com/Wrestler094/scalable-ecommerce-platform/order-service/internal/domain"
+)
+
+// ExpiryWorker периодически отменяет заказы, не оплаченные в течение TTL.
+type ExpiryWorker struct {
+	expiryUC domain.OrderExpiryUseCase
+	logger   logger.Logger
+	ttl      time.Duration
+	interval time.Duration
+	batch    int
+}
+
+func NewExpiryWorker(
+	expiryUC domain.OrderExpiryUseCase,
+	logger logger.Logger,
+	ttl time.Duration,
+	interval time.Duration,
+	batch int,
+) *ExpiryWorker {
+	return &ExpiryWorker{
+		expiryUC: expiryUC,
+		logger:   logger,
+		ttl:      ttl,
+		interval: interval,
+		batch:    batch,
+	}
+}
+
+func (w *ExpiryWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.process(ctx)
+		}
+	}
+}
+
+func (w *ExpiryWorker) process(ctx context.Context) {
+	const op = "worker.ExpiryWorker.process"
+
+	var total int
+	for ctx.Err() == nil {
+		expired, err := w.expiryUC.ExpireUnpaidOrders(ctx, w.ttl, w.batch)
+		if err != nil {
+			w.logger.WithOp(op).WithError(err).Error("failed to expire unpaid orders")
+			break
+		}
+
+		total += expired
+
+		// Неполная пачка — просроченных заказов больше нет
+		if expired < w.batch {
+			break
+		}
+	}
+
+	if total > 0 {
+		w.logger.WithOp(op).Info("expired unpaid orders", "count", total)
+	}
+}
@@ -0,0 +1,11 @@
+package domain
+
+import (
+	"context"
+)
+
+type CartProvider interface {
+	GetCart(ctx context.Context, userID int64) ([]OrderItemInput, error)
+	// RemoveCheckedOutItems убирает из корзины ровно оформленные позиции.
+	RemoveCheckedOutItems(ctx context.Context, userID int64, items []OrderItemInput) error
+}
@@ -1,7 +1,52 @@
 package domain
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 var (
-	ErrOrderNotFound = errors.New("order not found")
+	ErrOrderNotFound           = errors.New("order not found")
+	ErrInvalidStatusTransition = errors.New("invalid order status transition")
+	ErrOrderStatusConflict     = errors.New("order status was changed concurrently")
+	ErrOrderAccessDenied       = errors.New("order access denied")
+	ErrOrderCancelNotAllowed   = errors.New("order can no longer be cancelled by user")
+	ErrPaymentRetryNotAllowed  = errors.New("order payment cannot be retried")
+	ErrEventAlreadyProcessed   = errors.New("event already processed")
+	ErrInvalidOrderFilter      = errors.New("invalid order list filter")
+	ErrInvalidCursor           = errors.New("invalid cursor")
+	ErrInvalidOrderStatus      = errors.New("invalid order status")
+	ErrStatusCommentRequired   = errors.New("status change comment is required")
+	ErrCartEmpty               = errors.New("cart is empty")
+	ErrCartClearFailed         = errors.New("failed to remove checked out items from cart")
+
+	ErrIdempotencyKeyNotFound       = errors.New("idempotency key not found")
+	ErrIdempotencyKeyExists         = errors.New("idempotency key already exists")
+	ErrIdempotencyKeyReused         = errors.New("idempotency key reused with different request")
+	ErrIdempotencyRequestInProgress = errors.New("request with this idempotency key is in progress")
+
+	ErrReturnNotFound         = errors.New("return request not found")
+	ErrReturnNotAllowed       = errors.New("order is not eligible for return")
+	ErrReturnAlreadyRequested = errors.New("order already has a pending return request")
+	ErrReturnAlreadyResolved  = errors.New("return request is already resolved")
+	ErrInvalidReturnItems     = errors.New("invalid return items")
+	ErrInvalidReturnStatus    = errors.New("invalid return status")
+
+	ErrPaymentServiceUnavailable = errors.New("payment service unavailable")
+	ErrUnsupportedDeliveryMethod = errors.New("unsupported delivery method")
 )
+
+// StatusTransitionError — ошибка недопустимого перехода статуса заказа.
+// Сопоставляется с ErrInvalidStatusTransition через errors.Is.
+type StatusTransitionError struct {
+	From OrderStatus
+	To   OrderStatus
+}
+
+func (e *StatusTransitionError) Error() string {
+	return fmt.Sprintf("%s: %s -> %s", ErrInvalidStatusTransition, e.From, e.To)
+}
+
+func (e *StatusTransitionError) Is(target error) bool {
+	return target == ErrInvalidStatusTransition
+}
@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// IdempotencyRecord связывает Idempotency-Key запроса создания заказа с его результатом.
+// Ключ уникален в пределах пользователя.
+type IdempotencyRecord struct {
+	UserID int64
+	Key    string
+	// Fingerprint — хеш тела запроса, по нему повторный запрос сверяется с исходным
+	Fingerprint string
+	OrderUUID   string
+	PaymentURL  string
+	CreatedAt   time.Time
+	// CompletedAt пустой, пока исходный запрос не сохранил ответ
+	CompletedAt *time.Time
+}
+
+type IdempotencyRepository interface {
+	// Reserve сохраняет ключ вместе с созданным заказом.
+	// Возвращает ErrIdempotencyKeyExists, если ключ уже занят.
+	Reserve(ctx context.Context, record IdempotencyRecord) error
+	// Find возвращает ErrIdempotencyKeyNotFound, если ключ ещё не использовался.
+	Find(ctx context.Context, userID int64, key string) (IdempotencyRecord, error)
+	// Complete сохраняет ответ исходного запроса.
+	Complete(ctx context.Context, userID int64, key string, paymentURL string) error
+}
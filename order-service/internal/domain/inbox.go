@@ -0,0 +1,14 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// InboxRepository фиксирует обработанные входящие события.
+// MarkProcessed вызывается в той же транзакции, что и изменение заказа,
+// и возвращает ErrEventAlreadyProcessed, если событие уже было применено.
+type InboxRepository interface {
+	MarkProcessed(ctx context.Context, eventID uuid.UUID, eventType string) error
+}
@@ -3,24 +3,95 @@ package domain
 import (
 	"context"
 	"time"
+
+	"github.com/google/uuid"
 )
 
 // Domain entities
 
+// ExpiredCancelReason — причина отмены заказа, не оплаченного вовремя.
+const ExpiredCancelReason = "payment timeout"
+
 type OrderItem struct {
 	ProductID int64
 	Quantity  int
 	Price     float64
+	// RefundedQuantity и RefundedAmount — возвращённое по позиции в рамках одобренных возвратов
+	RefundedQuantity int
+	RefundedAmount   float64
+	// Product — данные товара на момент оформления заказа
+	Product ProductSnapshot
+}
+
+// ProductSnapshot фиксирует карточку товара в позиции заказа, чтобы последующие
+// изменения каталога не влияли на историю заказов, возвраты и налоги.
+type ProductSnapshot struct {
+	Name         string
+	SKU          string
+	CategoryID   int64
+	CategoryName string
+	TaxClass     string
+}
+
+// NewProductSnapshot снимает данные товара, полученные из каталога.
+func NewProductSnapshot(p Product) ProductSnapshot {
+	return ProductSnapshot{
+		Name:         p.Name,
+		SKU:          p.SKU,
+		CategoryID:   p.CategoryID,
+		CategoryName: p.CategoryName,
+		TaxClass:     p.TaxClass,
+	}
+}
+
+// ReturnableQuantity — количество товара по позиции, которое ещё можно вернуть.
+func (i OrderItem) ReturnableQuantity() int {
+	return i.Quantity - i.RefundedQuantity
 }
 
 type Order struct {
-	ID          int64
-	UUID        string
-	UserID      int64
-	Status      string
-	Items       []OrderItem
-	TotalAmount float64
-	CreatedAt   time.Time
+	ID           int64
+	UUID         string
+	UserID       int64
+	Status       OrderStatus
+	Items        []OrderItem
+	TotalAmount  float64
+	CreatedAt    time.Time
+	CancelReason string
+	CancelledAt  *time.Time
+	// PaymentFailureReason — причина последней неудачной попытки оплаты
+	PaymentFailureReason string
+	// RefundedAmount — сколько всего возвращено по заказу, в том числе частичными возвратами,
+	// которые не меняют статус заказа
+	RefundedAmount float64
+	// Shipping — доставка заказа, её стоимость входит в TotalAmount
+	Shipping Shipping
+}
+
+// Cancel переводит заказ в статус cancelled и сохраняет причину отмены.
+func (o *Order) Cancel(reason string, at time.Time) error {
+	if err := o.TransitionTo(OrderStatusCancelled); err != nil {
+		return err
+	}
+
+	o.CancelReason = reason
+	o.CancelledAt = &at
+	return nil
+}
+
+// Expire отменяет неоплаченный заказ по истечении срока оплаты.
+func (o *Order) Expire(at time.Time) error {
+	return o.Cancel(ExpiredCancelReason, at)
+}
+
+// FailPayment переводит заказ в статус payment_failed и сохраняет причину отказа.
+func (o *Order) FailPayment(reason string) error {
+	if err := o.TransitionTo(OrderStatusPaymentFailed); err != nil {
+		return err
+	}
+
+	o.PaymentFailureReason = reason
+	return nil
 }
 
 // UseCases
@@ -30,14 +101,64 @@ type OrderItemInput struct {
 	Quantity  int
 }
 
+// CreateOrderCommand — создание заказа пользователем.
+// При непустом IdempotencyKey повторный запрос с тем же ключом возвращает уже созданный заказ.
+type CreateOrderCommand struct {
+	UserID         int64
+	Items          []OrderItemInput
+	Shipping       ShippingInput
+	IdempotencyKey string
+}
+
+type CancelOrderCommand struct {
+	OrderUUID string
+	UserID    int64
+	IsAdmin   bool
+	Reason    string
+}
+
+// ChangeOrderStatusCommand — ручная смена статуса заказа администратором.
+// Comment обязателен и попадает в событие order_status_changed.
+type ChangeOrderStatusCommand struct {
+	OrderUUID string
+	AdminID   int64
+	Status    OrderStatus
+	Comment   string
+}
+
 type OrderUseCase interface {
-	CreateOrder(ctx context.Context, userID int64, items []OrderItemInput) (Order, string, error)
-	ListOrdersByUser(ctx context.Context, userID int64) ([]Order, error)
+	// CreateOrder возвращает непустой заказ, если он был сохранён, даже вместе с ошибкой создания платежа.
+	CreateOrder(ctx context.Context, cmd CreateOrderCommand) (Order, string, error)
+	Checkout(ctx context.Context, userID int64, shipping ShippingInput) (Order, string, error)
+	ListOrdersByUser(ctx context.Context, filter OrderListFilter) (OrderPage, error)
 	GetOrderByUUID(ctx context.Context, uuid string) (Order, error)
+	CancelOrder(ctx context.Context, cmd CancelOrderCommand) (Order, error)
+	RetryPayment(ctx context.Context, userID int64, uuid string) (Order, string, error)
+	// GetOrderHistory возвращает историю статусов заказа владельцу или администратору.
+	GetOrderHistory(ctx context.Context, userID int64, isAdmin bool, uuid string) ([]StatusHistoryEntry, error)
 }
 
+// OrderPaymentUseCase применяет события payment-service к заказу.
+// eventID — идентификатор входящего события, по нему отсекаются повторные доставки.
 type OrderPaymentUseCase interface {
-	MarkOrderAsPaid(ctx context.Context, uuid string) error
+	MarkOrderAsPaid(ctx context.Context, eventID uuid.UUID, orderUUID string) error
+	MarkOrderPaymentFailed(ctx context.Context, eventID uuid.UUID, orderUUID string, reason string) error
+	// MarkOrderRefunded учитывает возврат средств, инициированный не заявкой на возврат
+	// (отмена оплаченного заказа, ручной возврат администратором): сохраняет общую возвращённую
+	// сумму и переводит заказ в refunded или partially_refunded, если такой переход разрешён.
+	MarkOrderRefunded(ctx context.Context, eventID uuid.UUID, orderUUID string, totalRefunded float64, fullyRefunded bool) error
+}
+
+// OrderExpiryUseCase отменяет заказы, не оплаченные в течение ttl.
+// Возвращает число отменённых заказов.
+type OrderExpiryUseCase interface {
+	ExpireUnpaidOrders(ctx context.Context, ttl time.Duration, limit int) (int, error)
+}
+
+type AdminOrderUseCase interface {
+	ListOrders(ctx context.Context, filter OrderListFilter) (OrderPage, error)
+	GetOrder(ctx context.Context, uuid string) (Order, error)
+	ChangeOrderStatus(ctx context.Context, cmd ChangeOrderStatusCommand) (Order, error)
 }
 
 // Repositories
@@ -45,9 +166,27 @@ type OrderPaymentUseCase interface {
 type OrderRepository interface {
 	Create(ctx context.Context, order Order) error
 	FindByUUID(ctx context.Context, uuid string) (Order, error)
-	FindByUserID(ctx context.Context, userID int64) ([]Order, error)
+	// List возвращает не более filter.Limit заказов, отсортированных от новых к старым.
+	List(ctx context.Context, filter OrderListFilter) ([]Order, error)
+	UpdateStatus(ctx context.Context, order Order, from OrderStatus, change StatusChange) (time.Time, error)
+	// UpdateItemRefunds сохраняет возвращённые количество и сумму по позициям заказа.
+	UpdateItemRefunds(ctx context.Context, order Order) error
+	UpdateRefundedAmount(ctx context.Context, orderUUID string, amount float64) error
+	// FindUnpaidForUpdate блокирует до limit неоплаченных заказов старше before в текущей транзакции,
+	// пропуская заказы, уже заблокированные другими обработчиками.
+	FindUnpaidForUpdate(ctx context.Context, before time.Time, limit int) ([]Order, error)
+	// ListStatusHistory возвращает историю статусов заказа в порядке изменений.
+	ListStatusHistory(ctx context.Context, orderID int64) ([]StatusHistoryEntry, error)
 }
 
 type OrderPaymentRepository interface {
-	MarkAsPaid(ctx context.Context, orderUUID string) error
+	FindByUUID(ctx context.Context, uuid string) (Order, error)
+	// UpdateStatus атомарно сохраняет статус заказа и связанные с ним поля жизненного цикла.
+	// Вместе со статусом в историю записывается переход from -> order.Status с описанием change.
+	// Возвращает время перехода, записанное в историю, или ErrOrderStatusConflict,
+	// если текущий статус заказа уже не равен from.
+	UpdateStatus(ctx context.Context, order Order, from OrderStatus, change StatusChange) (time.Time, error)
+	// UpdateRefundedAmount сохраняет общую возвращённую сумму. Сумма не уменьшается,
+	// поэтому событие о более раннем возврате, доставленное позже, её не откатит.
+	UpdateRefundedAmount(ctx context.Context, orderUUID string, amount float64) error
 }
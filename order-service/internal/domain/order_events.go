@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// OrderStatusEvent — уведомление о смене статуса заказа для клиентов в реальном времени.
+type OrderStatusEvent struct {
+	OrderUUID  string
+	UserID     int64
+	FromStatus OrderStatus
+	Status     OrderStatus
+	OccurredAt time.Time
+}
+
+// OrderStatusSubscription — подписка на смену статусов одного заказа.
+// Канал Events закрывается после Close или при обрыве соединения с брокером.
+type OrderStatusSubscription interface {
+	Events() <-chan OrderStatusEvent
+	Close() error
+}
+
+// OrderEventPublisher рассылает уведомления всем репликам сервиса.
+// Доставка best-effort: ошибка публикации не должна откатывать смену статуса,
+// поэтому реализация сама логирует сбои.
+type OrderEventPublisher interface {
+	PublishStatusChanged(ctx context.Context, event OrderStatusEvent)
+}
+
+type OrderEventSubscriber interface {
+	SubscribeStatusChanges(ctx context.Context, orderUUID string) (OrderStatusSubscription, error)
+}
+
+type OrderEventsUseCase interface {
+	SubscribeStatusChanges(
+		ctx context.Context,
+		userID int64,
+		isAdmin bool,
+		orderUUID string,
+	) (Order, OrderStatusSubscription, error)
+}
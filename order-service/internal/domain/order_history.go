@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// StatusActor — инициатор смены статуса заказа.
+type StatusActor string
+
+const (
+	StatusActorUser   StatusActor = "user"
+	StatusActorAdmin  StatusActor = "admin"
+	StatusActorSystem StatusActor = "system"
+	StatusActorEvent  StatusActor = "event"
+)
+
+// StatusChange описывает, кто и почему меняет статус заказа.
+// Сохраняется в истории вместе с самим переходом.
+type StatusChange struct {
+	Actor   StatusActor
+	ActorID int64
+	Reason  string
+	// EventID — входящее событие, вызвавшее переход (для Actor = event)
+	EventID *uuid.UUID
+}
+
+// StatusHistoryEntry — запись истории статусов заказа.
+// FromStatus пустой у записи о создании заказа.
+type StatusHistoryEntry struct {
+	FromStatus OrderStatus
+	ToStatus   OrderStatus
+	StatusChange
+	CreatedAt time.Time
+}
+
+func UserStatusChange(userID int64, reason string) StatusChange {
+	return StatusChange{Actor: StatusActorUser, ActorID: userID, Reason: reason}
+}
+
+func AdminStatusChange(adminID int64, reason string) StatusChange {
+	return StatusChange{Actor: StatusActorAdmin, ActorID: adminID, Reason: reason}
+}
+
+func SystemStatusChange(reason string) StatusChange {
+	return StatusChange{Actor: StatusActorSystem, Reason: reason}
+}
+
+func EventStatusChange(eventID uuid.UUID, reason string) StatusChange {
+	return StatusChange{Actor: StatusActorEvent, EventID: &eventID, Reason: reason}
+}
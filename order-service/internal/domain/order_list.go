@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"encoding/base64"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultOrderListLimit = 20
+	MaxOrderListLimit     = 100
+)
+
+// OrderCursor — позиция последнего заказа на странице.
+// Заказы упорядочены по (created_at, id) по убыванию, id разрешает совпадения времени создания.
+type OrderCursor struct {
+	CreatedAt time.Time
+	ID        int64
+}
+
+// Encode упаковывает позицию страницы в непрозрачную для клиента строку.
+func (c OrderCursor) Encode() string {
+	raw := c.CreatedAt.UTC().Format(time.RFC3339Nano) + "|" + strconv.FormatInt(c.ID, 10)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeOrderCursor разбирает строку, полученную из OrderCursor.Encode.
+func DecodeOrderCursor(s string) (OrderCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return OrderCursor{}, ErrInvalidCursor
+	}
+
+	createdAt, id, ok := strings.Cut(string(raw), "|")
+	if !ok {
+		return OrderCursor{}, ErrInvalidCursor
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, createdAt)
+	if err != nil {
+		return OrderCursor{}, ErrInvalidCursor
+	}
+
+	orderID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return OrderCursor{}, ErrInvalidCursor
+	}
+
+	return OrderCursor{CreatedAt: t, ID: orderID}, nil
+}
+
+// OrderListFilter — параметры выборки заказов.
+// Нулевые значения фильтров означают отсутствие ограничения.
+type OrderListFilter struct {
+	UserID      int64
+	Status      OrderStatus
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	MinAmount   *float64
+	MaxAmount   *float64
+	Limit       int
+	Cursor      *OrderCursor
+}
+
+// OrderPage — страница заказов. NextCursor равен nil на последней странице.
+type OrderPage struct {
+	Orders     []Order
+	NextCursor *OrderCursor
+}
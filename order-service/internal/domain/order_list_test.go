@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestOrderCursorRoundTrip(t *testing.T) {
+	moscow := time.FixedZone("MSK", 3*60*60)
+
+	tests := []struct {
+		name   string
+		cursor OrderCursor
+	}{
+		{"utc time", OrderCursor{CreatedAt: time.Date(2025, 3, 1, 12, 30, 0, 0, time.UTC), ID: 42}},
+		{"nanoseconds kept", OrderCursor{CreatedAt: time.Date(2025, 3, 1, 12, 30, 0, 123456789, time.UTC), ID: 1}},
+		{"other time zone", OrderCursor{CreatedAt: time.Date(2025, 3, 1, 15, 30, 0, 0, moscow), ID: 7}},
+		{"large id", OrderCursor{CreatedAt: time.Date(2024, 12, 31, 23, 59, 59, 0, time.UTC), ID: 1<<63 - 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeOrderCursor(tt.cursor.Encode())
+			if err != nil {
+				t.Fatalf("DecodeOrderCursor() error = %v", err)
+			}
+			if !got.CreatedAt.Equal(tt.cursor.CreatedAt) || got.ID != tt.cursor.ID {
+				t.Errorf("DecodeOrderCursor() = %+v, want %+v", got, tt.cursor)
+			}
+		})
+	}
+}
+
+func TestDecodeOrderCursorMalformed(t *testing.T) {
+	encode := func(raw string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(raw))
+	}
+
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"empty", ""},
+		{"not base64", "!!!"},
+		{"padded base64", base64.URLEncoding.EncodeToString([]byte("2025-03-01T12:30:00Z|42"))},
+		{"no separator", encode("2025-03-01T12:30:00Z")},
+		{"bad time", encode("yesterday|42")},
+		{"bad id", encode("2025-03-01T12:30:00Z|forty-two")},
+		{"empty id", encode("2025-03-01T12:30:00Z|")},
+		{"id overflow", encode("2025-03-01T12:30:00Z|9223372036854775808")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := DecodeOrderCursor(tt.input); !errors.Is(err, ErrInvalidCursor) {
+				t.Errorf("DecodeOrderCursor(%q) error = %v, want %v", tt.input, err, ErrInvalidCursor)
+			}
+		})
+	}
+}
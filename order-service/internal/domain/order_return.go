@@ -0,0 +1,159 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// ReturnStatus — статус заявки на возврат.
+type ReturnStatus string
+
+const (
+	ReturnStatusRequested ReturnStatus = "requested"
+	ReturnStatusApproved  ReturnStatus = "approved"
+	ReturnStatusRejected  ReturnStatus = "rejected"
+)
+
+func (s ReturnStatus) IsValid() bool {
+	switch s {
+	case ReturnStatusRequested, ReturnStatusApproved, ReturnStatusRejected:
+		return true
+	default:
+		return false
+	}
+}
+
+// ReturnItem — возвращаемая позиция заказа. Amount — сумма к возврату по позиции.
+type ReturnItem struct {
+	ProductID int64
+	Quantity  int
+	Amount    float64
+}
+
+// OrderReturn — заявка пользователя на возврат позиций доставленного заказа.
+type OrderReturn struct {
+	UUID      string
+	OrderUUID string
+	UserID    int64
+	Status    ReturnStatus
+	Reason    string
+	Items     []ReturnItem
+	// RefundAmount — сумма всех позиций заявки
+	RefundAmount float64
+	CreatedAt    time.Time
+
+	ResolvedBy        int64
+	ResolutionComment string
+	ResolvedAt        *time.Time
+}
+
+// Resolve фиксирует решение администратора по заявке.
+func (r *OrderReturn) Resolve(status ReturnStatus, adminID int64, comment string, at time.Time) error {
+	if r.Status != ReturnStatusRequested {
+		return ErrReturnAlreadyResolved
+	}
+
+	r.Status = status
+	r.ResolvedBy = adminID
+	r.ResolutionComment = comment
+	r.ResolvedAt = &at
+	return nil
+}
+
+// ApplyReturn учитывает возвращённые позиции в заказе и переводит его
+// в partially_refunded или refunded, если вернули все позиции.
+// Возвращает сумму к возврату: стоимость позиций заявки, а при возврате последних
+// позиций — вместе с доставкой, чтобы refunded означал возврат всей оплаты.
+func (o *Order) ApplyReturn(items []ReturnItem) (float64, error) {
+	var amount float64
+	for _, ri := range items {
+		idx := o.itemIndex(ri.ProductID)
+		if idx < 0 || ri.Quantity > o.Items[idx].ReturnableQuantity() {
+			return 0, ErrInvalidReturnItems
+		}
+
+		o.Items[idx].RefundedQuantity += ri.Quantity
+		o.Items[idx].RefundedAmount += ri.Amount
+		amount += ri.Amount
+	}
+
+	to := OrderStatusRefunded
+	for _, item := range o.Items {
+		if item.ReturnableQuantity() > 0 {
+			to = OrderStatusPartiallyRefunded
+			break
+		}
+	}
+
+	if to == OrderStatusRefunded {
+		amount += o.Shipping.Cost
+	}
+
+	// Очередной частичный возврат не меняет статус
+	if o.Status == to {
+		return amount, nil
+	}
+	if err := o.TransitionTo(to); err != nil {
+		return 0, err
+	}
+	return amount, nil
+}
+
+func (o *Order) itemIndex(productID int64) int {
+	for i, item := range o.Items {
+		if item.ProductID == productID {
+			return i
+		}
+	}
+	return -1
+}
+
+// UseCases
+
+type ReturnItemInput struct {
+	ProductID int64
+	Quantity  int
+}
+
+type RequestReturnCommand struct {
+	OrderUUID string
+	UserID    int64
+	Reason    string
+	Items     []ReturnItemInput
+}
+
+// ResolveReturnCommand — решение администратора по заявке на возврат.
+type ResolveReturnCommand struct {
+	ReturnUUID string
+	AdminID    int64
+	Comment    string
+}
+
+type ReturnUseCase interface {
+	RequestReturn(ctx context.Context, cmd RequestReturnCommand) (OrderReturn, error)
+	ListOrderReturns(ctx context.Context, userID int64, isAdmin bool, orderUUID string) ([]OrderReturn, error)
+}
+
+type AdminReturnUseCase interface {
+	ListReturns(ctx context.Context, status ReturnStatus) ([]OrderReturn, error)
+	// ApproveReturn учитывает возврат в заказе и публикует событие для возврата средств.
+	ApproveReturn(ctx context.Context, cmd ResolveReturnCommand) (OrderReturn, error)
+	RejectReturn(ctx context.Context, cmd ResolveReturnCommand) (OrderReturn, error)
+}
+
+// Repositories
+
+// MaxReturnListLimit — максимальное число заявок в списке для администратора.
+const MaxReturnListLimit = 100
+
+type ReturnRepository interface {
+	// Create возвращает ErrReturnAlreadyRequested, если по заказу уже есть нерассмотренная заявка.
+	Create(ctx context.Context, ret OrderReturn) error
+	FindByUUID(ctx context.Context, uuid string) (OrderReturn, error)
+	ListByOrder(ctx context.Context, orderUUID string) ([]OrderReturn, error)
+	// ListByStatus возвращает не более limit заявок, от старых к новым.
+	ListByStatus(ctx context.Context, status ReturnStatus, limit int) ([]OrderReturn, error)
+	// Resolve сохраняет решение по заявке.
+	// Возвращает ErrReturnAlreadyResolved, если заявка уже рассмотрена.
+	Resolve(ctx context.Context, ret OrderReturn) error
+}
@@ -0,0 +1,126 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func returnTestOrder(status OrderStatus) Order {
+	return Order{
+		Status: status,
+		Items: []OrderItem{
+			{ProductID: 1, Quantity: 2, Price: 10},
+			{ProductID: 2, Quantity: 1, Price: 5},
+		},
+		TotalAmount: 32,
+		Shipping:    Shipping{Cost: 7},
+	}
+}
+
+func TestOrderApplyReturn(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     OrderStatus
+		refunded   map[int64]int
+		items      []ReturnItem
+		wantStatus OrderStatus
+		wantAmount float64
+		wantErr    error
+	}{
+		{
+			name:       "part of one line",
+			status:     OrderStatusDelivered,
+			items:      []ReturnItem{{ProductID: 1, Quantity: 1, Amount: 10}},
+			wantStatus: OrderStatusPartiallyRefunded,
+			wantAmount: 10,
+		},
+		{
+			name:       "whole line of several",
+			status:     OrderStatusDelivered,
+			items:      []ReturnItem{{ProductID: 2, Quantity: 1, Amount: 5}},
+			wantStatus: OrderStatusPartiallyRefunded,
+			wantAmount: 5,
+		},
+		{
+			name:       "repeated partial return keeps status",
+			status:     OrderStatusPartiallyRefunded,
+			refunded:   map[int64]int{1: 1},
+			items:      []ReturnItem{{ProductID: 1, Quantity: 1, Amount: 10}},
+			wantStatus: OrderStatusPartiallyRefunded,
+			wantAmount: 10,
+		},
+		{
+			name:       "everything at once refunds shipping",
+			status:     OrderStatusDelivered,
+			items:      []ReturnItem{{ProductID: 1, Quantity: 2, Amount: 20}, {ProductID: 2, Quantity: 1, Amount: 5}},
+			wantStatus: OrderStatusRefunded,
+			wantAmount: 32,
+		},
+		{
+			name:       "last items refund shipping",
+			status:     OrderStatusPartiallyRefunded,
+			refunded:   map[int64]int{1: 2},
+			items:      []ReturnItem{{ProductID: 2, Quantity: 1, Amount: 5}},
+			wantStatus: OrderStatusRefunded,
+			wantAmount: 12,
+		},
+		{
+			name:       "more than returnable",
+			status:     OrderStatusPartiallyRefunded,
+			refunded:   map[int64]int{1: 1},
+			items:      []ReturnItem{{ProductID: 1, Quantity: 2, Amount: 20}},
+			wantStatus: OrderStatusPartiallyRefunded,
+			wantErr:    ErrInvalidReturnItems,
+		},
+		{
+			name:       "product not in order",
+			status:     OrderStatusDelivered,
+			items:      []ReturnItem{{ProductID: 3, Quantity: 1, Amount: 1}},
+			wantStatus: OrderStatusDelivered,
+			wantErr:    ErrInvalidReturnItems,
+		},
+		{
+			name:       "order not delivered",
+			status:     OrderStatusShipped,
+			items:      []ReturnItem{{ProductID: 1, Quantity: 1, Amount: 10}},
+			wantStatus: OrderStatusShipped,
+			wantErr:    ErrInvalidStatusTransition,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := returnTestOrder(tt.status)
+			for i, item := range order.Items {
+				order.Items[i].RefundedQuantity = tt.refunded[item.ProductID]
+			}
+
+			amount, err := order.ApplyReturn(tt.items)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ApplyReturn() error = %v, want %v", err, tt.wantErr)
+			}
+			if order.Status != tt.wantStatus {
+				t.Errorf("status = %s, want %s", order.Status, tt.wantStatus)
+			}
+			if err == nil && amount != tt.wantAmount {
+				t.Errorf("amount = %v, want %v", amount, tt.wantAmount)
+			}
+		})
+	}
+}
+
+func TestOrderApplyReturnUpdatesItems(t *testing.T) {
+	order := returnTestOrder(OrderStatusDelivered)
+
+	if _, err := order.ApplyReturn([]ReturnItem{{ProductID: 1, Quantity: 1, Amount: 10}}); err != nil {
+		t.Fatalf("ApplyReturn() error = %v", err)
+	}
+
+	item := order.Items[0]
+	if item.RefundedQuantity != 1 || item.RefundedAmount != 10 {
+		t.Errorf("refunded quantity, amount = %d, %v, want 1, 10", item.RefundedQuantity, item.RefundedAmount)
+	}
+	if item.ReturnableQuantity() != 1 {
+		t.Errorf("ReturnableQuantity() = %d, want 1", item.ReturnableQuantity())
+	}
+}
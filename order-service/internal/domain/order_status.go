@@ -0,0 +1,92 @@
+package domain
+
+import "slices"
+
+// OrderStatus — статус жизненного цикла заказа.
+type OrderStatus string
+
+const (
+	OrderStatusPending         OrderStatus = "pending"
+	OrderStatusAwaitingPayment OrderStatus = "awaiting_payment"
+	OrderStatusPaymentFailed   OrderStatus = "payment_failed"
+	OrderStatusPaid            OrderStatus = "paid"
+	OrderStatusProcessing      OrderStatus = "processing"
+	OrderStatusShipped         OrderStatus = "shipped"
+	OrderStatusDelivered       OrderStatus = "delivered"
+	OrderStatusCancelled       OrderStatus = "cancelled"
+	OrderStatusRefunded        OrderStatus = "refunded"
+	// OrderStatusPartiallyRefunded — по заказу одобрен возврат части позиций
+	OrderStatusPartiallyRefunded OrderStatus = "partially_refunded"
+)
+
+// orderTransitions описывает допустимые переходы между статусами заказа.
+// Статусы, отсутствующие в качестве ключа, считаются терминальными.
+var orderTransitions = map[OrderStatus][]OrderStatus{
+	OrderStatusPending:         {OrderStatusAwaitingPayment, OrderStatusPaymentFailed, OrderStatusPaid, OrderStatusCancelled},
+	OrderStatusAwaitingPayment: {OrderStatusPaymentFailed, OrderStatusPaid, OrderStatusCancelled},
+	// После неудачной оплаты пользователь может повторить платёж по тому же заказу
+	OrderStatusPaymentFailed: {OrderStatusAwaitingPayment, OrderStatusPaid, OrderStatusCancelled},
+	OrderStatusPaid:          {OrderStatusProcessing, OrderStatusCancelled, OrderStatusRefunded},
+	OrderStatusProcessing:    {OrderStatusShipped, OrderStatusCancelled, OrderStatusRefunded},
+	OrderStatusShipped:       {OrderStatusDelivered, OrderStatusCancelled},
+	OrderStatusDelivered:     {OrderStatusPartiallyRefunded, OrderStatusRefunded},
+	// Повторные возвраты оставляют заказ в partially_refunded, пока не возвращены все позиции
+	OrderStatusPartiallyRefunded: {OrderStatusRefunded},
+	// Отменённый оплаченный заказ переходит в refunded после возврата средств
+	OrderStatusCancelled: {OrderStatusRefunded},
+}
+
+// IsValid сообщает, является ли значение известным статусом заказа.
+func (s OrderStatus) IsValid() bool {
+	switch s {
+	case OrderStatusPending, OrderStatusAwaitingPayment, OrderStatusPaymentFailed, OrderStatusPaid, OrderStatusProcessing,
+		OrderStatusShipped, OrderStatusDelivered, OrderStatusCancelled, OrderStatusRefunded, OrderStatusPartiallyRefunded:
+		return true
+	default:
+		return false
+	}
+}
+
+// AwaitingPaymentStatuses — статусы неоплаченного заказа.
+var AwaitingPaymentStatuses = []OrderStatus{OrderStatusPending, OrderStatusAwaitingPayment, OrderStatusPaymentFailed}
+
+// IsAwaitingPayment сообщает, что заказ ещё не оплачен и может быть отменён владельцем.
+func (s OrderStatus) IsAwaitingPayment() bool {
+	return slices.Contains(AwaitingPaymentStatuses, s)
+}
+
+// IsPaid сообщает, что по заказу получена оплата, которую при отмене нужно вернуть.
+func (s OrderStatus) IsPaid() bool {
+	switch s {
+	case OrderStatusPaid, OrderStatusProcessing, OrderStatusShipped, OrderStatusDelivered:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsReturnable сообщает, что по заказу можно оформить возврат позиций.
+func (s OrderStatus) IsReturnable() bool {
+	return s == OrderStatusDelivered || s == OrderStatusPartiallyRefunded
+}
+
+// CanTransitionTo сообщает, разрешён ли переход из текущего статуса в статус to.
+func (s OrderStatus) CanTransitionTo(to OrderStatus) bool {
+	for _, allowed := range orderTransitions[s] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// TransitionTo переводит заказ в статус to, если переход разрешён.
+// В противном случае возвращает *StatusTransitionError, статус заказа не меняется.
+func (o *Order) TransitionTo(to OrderStatus) error {
+	if !o.Status.CanTransitionTo(to) {
+		return &StatusTransitionError{From: o.Status, To: to}
+	}
+
+	o.Status = to
+	return nil
+}
@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestOrderStatusCanTransitionTo(t *testing.T) {
+	tests := []struct {
+		name string
+		from OrderStatus
+		to   OrderStatus
+		want bool
+	}{
+		{"pending to awaiting payment", OrderStatusPending, OrderStatusAwaitingPayment, true},
+		{"pending to paid", OrderStatusPending, OrderStatusPaid, true},
+		{"pending to cancelled", OrderStatusPending, OrderStatusCancelled, true},
+		{"awaiting payment to payment failed", OrderStatusAwaitingPayment, OrderStatusPaymentFailed, true},
+		{"payment failed to awaiting payment on retry", OrderStatusPaymentFailed, OrderStatusAwaitingPayment, true},
+		{"paid to processing", OrderStatusPaid, OrderStatusProcessing, true},
+		{"paid to refunded", OrderStatusPaid, OrderStatusRefunded, true},
+		{"processing to shipped", OrderStatusProcessing, OrderStatusShipped, true},
+		{"shipped to delivered", OrderStatusShipped, OrderStatusDelivered, true},
+		{"delivered to partially refunded", OrderStatusDelivered, OrderStatusPartiallyRefunded, true},
+		{"partially refunded to refunded", OrderStatusPartiallyRefunded, OrderStatusRefunded, true},
+		{"cancelled to refunded", OrderStatusCancelled, OrderStatusRefunded, true},
+
+		{"pending to shipped", OrderStatusPending, OrderStatusShipped, false},
+		{"awaiting payment to pending", OrderStatusAwaitingPayment, OrderStatusPending, false},
+		{"paid to awaiting payment", OrderStatusPaid, OrderStatusAwaitingPayment, false},
+		{"paid to partially refunded", OrderStatusPaid, OrderStatusPartiallyRefunded, false},
+		{"shipped to refunded", OrderStatusShipped, OrderStatusRefunded, false},
+		{"delivered to cancelled", OrderStatusDelivered, OrderStatusCancelled, false},
+		{"cancelled to paid", OrderStatusCancelled, OrderStatusPaid, false},
+		{"refunded is terminal", OrderStatusRefunded, OrderStatusPartiallyRefunded, false},
+		{"same status", OrderStatusPaid, OrderStatusPaid, false},
+		{"unknown status", OrderStatus("lost"), OrderStatusPaid, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.from.CanTransitionTo(tt.to); got != tt.want {
+				t.Errorf("%s.CanTransitionTo(%s) = %v, want %v", tt.from, tt.to, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOrderTransitionTo(t *testing.T) {
+	order := Order{Status: OrderStatusShipped}
+
+	err := order.TransitionTo(OrderStatusPaid)
+
+	var transitionErr *StatusTransitionError
+	if !errors.As(err, &transitionErr) {
+		t.Fatalf("TransitionTo(paid) error = %v, want *StatusTransitionError", err)
+	}
+	if order.Status != OrderStatusShipped {
+		t.Errorf("status after rejected transition = %s, want %s", order.Status, OrderStatusShipped)
+	}
+
+	if err := order.TransitionTo(OrderStatusDelivered); err != nil {
+		t.Fatalf("TransitionTo(delivered) error = %v", err)
+	}
+	if order.Status != OrderStatusDelivered {
+		t.Errorf("status = %s, want %s", order.Status, OrderStatusDelivered)
+	}
+}
@@ -10,6 +10,10 @@ type Product struct {
 	Name        string
 	Description string
 	CategoryID  int64
+
+	SKU          string
+	TaxClass     string
+	CategoryName string
 }
 
 type ProductProvider interface {
@@ -0,0 +1,55 @@
+package domain
+
+// DeliveryMethod — способ доставки заказа.
+type DeliveryMethod string
+
+const (
+	DeliveryMethodCourier DeliveryMethod = "courier"
+	DeliveryMethodPost    DeliveryMethod = "post"
+	DeliveryMethodPickup  DeliveryMethod = "pickup"
+)
+
+func (m DeliveryMethod) IsValid() bool {
+	switch m {
+	case DeliveryMethodCourier, DeliveryMethodPost, DeliveryMethodPickup:
+		return true
+	default:
+		return false
+	}
+}
+
+type ShippingAddress struct {
+	Recipient string
+	Phone     string
+	// Country — код страны ISO 3166-1 alpha-2
+	Country    string
+	City       string
+	Street     string
+	PostalCode string
+}
+
+// ShippingInput — данные доставки из запроса на создание заказа.
+// Адрес всегда передаётся целиком: сохранённых адресов пользователя пока нет.
+type ShippingInput struct {
+	Address ShippingAddress
+	Method  DeliveryMethod
+}
+
+// Shipping — доставка заказа. Cost входит в TotalAmount заказа.
+type Shipping struct {
+	Address ShippingAddress
+	Method  DeliveryMethod
+	Cost    float64
+}
+
+// ShippingRates — стоимость доставки по способам доставки.
+type ShippingRates map[DeliveryMethod]float64
+
+// Cost возвращает стоимость доставки выбранным способом.
+func (r ShippingRates) Cost(method DeliveryMethod) (float64, error) {
+	cost, ok := r[method]
+	if !ok {
+		return 0, ErrUnsupportedDeliveryMethod
+	}
+	return cost, nil
+}
@@ -0,0 +1,72 @@
+package cart
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	cartv1 "github.com/Wrestler094/scalable-ecommerce-platform/gen/go/cart/v1"
+	"github.com/Wrestler094/scalable-ecommerce-platform/order-service/internal/domain"
+)
+
+type Client struct {
+	client cartv1.CartServiceClient
+}
+
+var _ domain.CartProvider = (*Client)(nil)
+
+func NewClient(ctx context.Context, grpcURL string) (*Client, error) {
+	conn, err := grpc.NewClient(grpcURL, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to cart service: %w", err)
+	}
+
+	go func() {
+		<-ctx.Done()
+		conn.Close()
+	}()
+
+	return &Client{
+		client: cartv1.NewCartServiceClient(conn),
+	}, nil
+}
+
+func (c *Client) GetCart(ctx context.Context, userID int64) ([]domain.OrderItemInput, error) {
+	const op = "cart.Client.GetCart"
+
+	resp, err := c.client.GetCart(ctx, &cartv1.GetCartRequest{UserId: userID})
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get cart from cart service: %w", op, err)
+	}
+
+	items := make([]domain.OrderItemInput, 0, len(resp.GetItems()))
+	for _, item := range resp.GetItems() {
+		items = append(items, domain.OrderItemInput{
+			ProductID: item.GetProductId(),
+			Quantity:  int(item.GetQuantity()),
+		})
+	}
+
+	return items, nil
+}
+
+func (c *Client) RemoveCheckedOutItems(ctx context.Context, userID int64, items []domain.OrderItemInput) error {
+	const op = "cart.Client.RemoveCheckedOutItems"
+
+	pbItems := make([]*cartv1.CartItem, 0, len(items))
+	for _, item := range items {
+		pbItems = append(pbItems, &cartv1.CartItem{
+			ProductId: item.ProductID,
+			Quantity:  int32(item.Quantity),
+		})
+	}
+
+	_, err := c.client.RemoveCheckedOutItems(ctx, &cartv1.RemoveCheckedOutItemsRequest{UserId: userID, Items: pbItems})
+	if err != nil {
+		return fmt.Errorf("%s: failed to remove items from cart: %w", op, err)
+	}
+
+	return nil
+}
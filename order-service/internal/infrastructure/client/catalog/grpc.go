@@ -48,6 +48,10 @@ func (c *Client) GetProductsByIDs(ctx context.Context, ids []int64) ([]domain.Pr
 			Name:        p.Name,
 			Description: p.Description,
 			CategoryID:  p.CategoryId,
+
+			SKU:          p.Sku,
+			TaxClass:     p.TaxClass,
+			CategoryName: p.CategoryName,
 		}
 	}
 
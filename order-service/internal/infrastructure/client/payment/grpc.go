@@ -0,0 +1,103 @@
+package payment
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	paymentv1 "github.com/Wrestler094/scalable-ecommerce-platform/gen/go/payment/v1"
+	"github.com/Wrestler094/scalable-ecommerce-platform/order-service/internal/domain"
+)
+
+type Config struct {
+	URL          string
+	Timeout      time.Duration
+	MaxRetries   int
+	RetryBackoff time.Duration
+}
+
+type Client struct {
+	client       paymentv1.PaymentServiceClient
+	timeout      time.Duration
+	maxRetries   int
+	retryBackoff time.Duration
+}
+
+var _ domain.PaymentService = (*Client)(nil)
+
+func NewClient(ctx context.Context, cfg Config) (*Client, error) {
+	conn, err := grpc.NewClient(cfg.URL, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to payment service: %w", err)
+	}
+
+	go func() {
+		<-ctx.Done()
+		conn.Close()
+	}()
+
+	return &Client{
+		client:       paymentv1.NewPaymentServiceClient(conn),
+		timeout:      cfg.Timeout,
+		maxRetries:   cfg.MaxRetries,
+		retryBackoff: cfg.RetryBackoff,
+	}, nil
+}
+
+// CreatePayment регистрирует намерение оплаты заказа в payment-service и возвращает ссылку на оплату.
+// Временные ошибки повторяются с экспоненциальной задержкой: вызов идемпотентен по UUID заказа.
+func (c *Client) CreatePayment(ctx context.Context, order domain.Order) (string, error) {
+	const op = "payment.Client.CreatePayment"
+
+	req := &paymentv1.CreatePaymentIntentRequest{
+		OrderUuid: order.UUID,
+		UserId:    order.UserID,
+		Amount:    order.TotalAmount,
+	}
+
+	backoff := c.retryBackoff
+	var err error
+	for attempt := 0; attempt <= c.maxRetries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return "", fmt.Errorf("%s: %w: %w", op, domain.ErrPaymentServiceUnavailable, ctx.Err())
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+		}
+
+		var resp *paymentv1.CreatePaymentIntentResponse
+		resp, err = c.createIntent(ctx, req)
+		if err == nil {
+			return resp.GetCheckoutUrl(), nil
+		}
+
+		if !isRetryable(err) {
+			return "", fmt.Errorf("%s: failed to create payment intent: %w", op, err)
+		}
+	}
+
+	return "", fmt.Errorf("%s: %w: %w", op, domain.ErrPaymentServiceUnavailable, err)
+}
+
+func (c *Client) createIntent(ctx context.Context, req *paymentv1.CreatePaymentIntentRequest) (*paymentv1.CreatePaymentIntentResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	return c.client.CreatePaymentIntent(ctx, req)
+}
+
+func isRetryable(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,34 @@
+package dao
+
+import (
+	"time"
+
+	"github.com/Wrestler094/scalable-ecommerce-platform/order-service/internal/domain"
+)
+
+type DBIdempotencyRecord struct {
+	UserID      int64      `db:"user_id"`
+	Key         string     `db:"key"`
+	Fingerprint string     `db:"fingerprint"`
+	OrderUUID   string     `db:"order_uuid"`
+	PaymentURL  *string    `db:"payment_url"`
+	CreatedAt   time.Time  `db:"created_at"`
+	CompletedAt *time.Time `db:"completed_at"`
+}
+
+// ======= Converters ========
+
+func ToDomainIdempotencyRecord(r DBIdempotencyRecord) domain.IdempotencyRecord {
+	record := domain.IdempotencyRecord{
+		UserID:      r.UserID,
+		Key:         r.Key,
+		Fingerprint: r.Fingerprint,
+		OrderUUID:   r.OrderUUID,
+		CreatedAt:   r.CreatedAt,
+		CompletedAt: r.CompletedAt,
+	}
+	if r.PaymentURL != nil {
+		record.PaymentURL = *r.PaymentURL
+	}
+	return record
+}
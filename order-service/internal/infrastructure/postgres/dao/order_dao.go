@@ -7,12 +7,27 @@ import (
 )
 
 type DBOrder struct {
-	ID          int64     `db:"id"`
-	UUID        string    `db:"uuid"`
-	UserID      int64     `db:"user_id"`
-	Status      string    `db:"status"`
-	TotalAmount float64   `db:"total_amount"`
-	CreatedAt   time.Time `db:"created_at"`
+	ID           int64      `db:"id"`
+	UUID         string     `db:"uuid"`
+	UserID       int64      `db:"user_id"`
+	Status       string     `db:"status"`
+	TotalAmount  float64    `db:"total_amount"`
+	CreatedAt    time.Time  `db:"created_at"`
+	CancelReason *string    `db:"cancel_reason"`
+	CancelledAt  *time.Time `db:"cancelled_at"`
+
+	PaymentFailureReason *string `db:"payment_failure_reason"`
+	RefundedAmount       float64 `db:"refunded_amount"`
+
+	// Поля доставки пустые у заказов, созданных до появления доставки
+	ShippingRecipient  *string `db:"shipping_recipient"`
+	ShippingPhone      *string `db:"shipping_phone"`
+	ShippingCountry    *string `db:"shipping_country"`
+	ShippingCity       *string `db:"shipping_city"`
+	ShippingStreet     *string `db:"shipping_street"`
+	ShippingPostalCode *string `db:"shipping_postal_code"`
+	DeliveryMethod     *string `db:"delivery_method"`
+	ShippingCost       float64 `db:"shipping_cost"`
 }
 
 type DBOrderItem struct {
@@ -20,10 +35,81 @@ type DBOrderItem struct {
 	ProductID int64   `db:"product_id"`
 	Quantity  int     `db:"quantity"`
 	Price     float64 `db:"price"`
+
+	RefundedQuantity int     `db:"refunded_quantity"`
+	RefundedAmount   float64 `db:"refunded_amount"`
+
+	ProductName  *string `db:"product_name"`
+	SKU          *string `db:"sku"`
+	CategoryID   *int64  `db:"category_id"`
+	CategoryName *string `db:"category_name"`
+	TaxClass     *string `db:"tax_class"`
 }
 
 // ======= Converots ========
 
+func ToDomainOrder(o DBOrder) domain.Order {
+	order := domain.Order{
+		ID:          o.ID,
+		UUID:        o.UUID,
+		UserID:      o.UserID,
+		Status:      domain.OrderStatus(o.Status),
+		TotalAmount: o.TotalAmount,
+		CreatedAt:   o.CreatedAt,
+		CancelledAt: o.CancelledAt,
+		Items:       []domain.OrderItem{},
+	}
+	if o.CancelReason != nil {
+		order.CancelReason = *o.CancelReason
+	}
+	if o.PaymentFailureReason != nil {
+		order.PaymentFailureReason = *o.PaymentFailureReason
+	}
+	order.RefundedAmount = o.RefundedAmount
+	order.Shipping = domain.Shipping{
+		Address: domain.ShippingAddress{
+			Recipient:  deref(o.ShippingRecipient),
+			Phone:      deref(o.ShippingPhone),
+			Country:    deref(o.ShippingCountry),
+			City:       deref(o.ShippingCity),
+			Street:     deref(o.ShippingStreet),
+			PostalCode: deref(o.ShippingPostalCode),
+		},
+		Method: domain.DeliveryMethod(deref(o.DeliveryMethod)),
+		Cost:   o.ShippingCost,
+	}
+	return order
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// ref — обратное к deref: пустая строка сохраняется как NULL.
+func ref(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+func derefInt64(v *int64) int64 {
+	if v == nil {
+		return 0
+	}
+	return *v
+}
+
+func refInt64(v int64) *int64 {
+	if v == 0 {
+		return nil
+	}
+	return &v
+}
+
 func ToDBOrderItems(orderID int64, items []domain.OrderItem) []DBOrderItem {
 	dbItems := make([]DBOrderItem, len(items))
 	for i, item := range items {
@@ -32,6 +118,12 @@ func ToDBOrderItems(orderID int64, items []domain.OrderItem) []DBOrderItem {
 			ProductID: item.ProductID,
 			Quantity:  item.Quantity,
 			Price:     item.Price,
+
+			ProductName:  ref(item.Product.Name),
+			SKU:          ref(item.Product.SKU),
+			CategoryID:   refInt64(item.Product.CategoryID),
+			CategoryName: ref(item.Product.CategoryName),
+			TaxClass:     ref(item.Product.TaxClass),
 		}
 	}
 	return dbItems
@@ -39,8 +131,17 @@ func ToDBOrderItems(orderID int64, items []domain.OrderItem) []DBOrderItem {
 
 func ToDomainOrderItem(item DBOrderItem) domain.OrderItem {
 	return domain.OrderItem{
-		ProductID: item.ProductID,
-		Quantity:  item.Quantity,
-		Price:     item.Price,
+		ProductID:        item.ProductID,
+		Quantity:         item.Quantity,
+		Price:            item.Price,
+		RefundedQuantity: item.RefundedQuantity,
+		RefundedAmount:   item.RefundedAmount,
+		Product: domain.ProductSnapshot{
+			Name:         deref(item.ProductName),
+			SKU:          deref(item.SKU),
+			CategoryID:   derefInt64(item.CategoryID),
+			CategoryName: deref(item.CategoryName),
+			TaxClass:     deref(item.TaxClass),
+		},
 	}
 }
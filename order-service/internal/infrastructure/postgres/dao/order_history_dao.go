@@ -0,0 +1,42 @@
+package dao
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Wrestler094/scalable-ecommerce-platform/order-service/internal/domain"
+)
+
+type DBStatusHistoryEntry struct {
+	FromStatus *string    `db:"from_status"`
+	ToStatus   string     `db:"to_status"`
+	Actor      string     `db:"actor"`
+	ActorID    *int64     `db:"actor_id"`
+	Reason     *string    `db:"reason"`
+	EventID    *uuid.UUID `db:"event_id"`
+	CreatedAt  time.Time  `db:"created_at"`
+}
+
+// ======= Converters ========
+
+func ToDomainStatusHistoryEntry(e DBStatusHistoryEntry) domain.StatusHistoryEntry {
+	entry := domain.StatusHistoryEntry{
+		ToStatus: domain.OrderStatus(e.ToStatus),
+		StatusChange: domain.StatusChange{
+			Actor:   domain.StatusActor(e.Actor),
+			EventID: e.EventID,
+		},
+		CreatedAt: e.CreatedAt,
+	}
+	if e.FromStatus != nil {
+		entry.FromStatus = domain.OrderStatus(*e.FromStatus)
+	}
+	if e.ActorID != nil {
+		entry.ActorID = *e.ActorID
+	}
+	if e.Reason != nil {
+		entry.Reason = *e.Reason
+	}
+	return entry
+}
@@ -0,0 +1,73 @@
+package dao
+
+import (
+	"time"
+
+	"github.com/Wrestler094/scalable-ecommerce-platform/order-service/internal/domain"
+)
+
+type DBOrderReturn struct {
+	ID           int64     `db:"id"`
+	UUID         string    `db:"uuid"`
+	OrderUUID    string    `db:"order_uuid"`
+	UserID       int64     `db:"user_id"`
+	Status       string    `db:"status"`
+	Reason       string    `db:"reason"`
+	RefundAmount float64   `db:"refund_amount"`
+	CreatedAt    time.Time `db:"created_at"`
+
+	ResolvedBy        *int64     `db:"resolved_by"`
+	ResolutionComment *string    `db:"resolution_comment"`
+	ResolvedAt        *time.Time `db:"resolved_at"`
+}
+
+type DBOrderReturnItem struct {
+	ReturnID  int64   `db:"return_id"`
+	ProductID int64   `db:"product_id"`
+	Quantity  int     `db:"quantity"`
+	Amount    float64 `db:"amount"`
+}
+
+// ======= Converters ========
+
+func ToDomainOrderReturn(r DBOrderReturn) domain.OrderReturn {
+	ret := domain.OrderReturn{
+		UUID:         r.UUID,
+		OrderUUID:    r.OrderUUID,
+		UserID:       r.UserID,
+		Status:       domain.ReturnStatus(r.Status),
+		Reason:       r.Reason,
+		Items:        []domain.ReturnItem{},
+		RefundAmount: r.RefundAmount,
+		CreatedAt:    r.CreatedAt,
+		ResolvedAt:   r.ResolvedAt,
+	}
+	if r.ResolvedBy != nil {
+		ret.ResolvedBy = *r.ResolvedBy
+	}
+	if r.ResolutionComment != nil {
+		ret.ResolutionComment = *r.ResolutionComment
+	}
+	return ret
+}
+
+func ToDBOrderReturnItems(returnID int64, items []domain.ReturnItem) []DBOrderReturnItem {
+	dbItems := make([]DBOrderReturnItem, len(items))
+	for i, item := range items {
+		dbItems[i] = DBOrderReturnItem{
+			ReturnID:  returnID,
+			ProductID: item.ProductID,
+			Quantity:  item.Quantity,
+			Amount:    item.Amount,
+		}
+	}
+	return dbItems
+}
+
+func ToDomainReturnItem(item DBOrderReturnItem) domain.ReturnItem {
+	return domain.ReturnItem{
+		ProductID: item.ProductID,
+		Quantity:  item.Quantity,
+		Amount:    item.Amount,
+	}
+}
@@ -0,0 +1,88 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/Wrestler094/scalable-ecommerce-platform/order-service/internal/domain"
+	"github.com/Wrestler094/scalable-ecommerce-platform/order-service/internal/infrastructure/postgres/dao"
+	"github.com/Wrestler094/scalable-ecommerce-platform/order-service/internal/infrastructure/txmanager"
+)
+
+var _ domain.IdempotencyRepository = (*IdempotencyRepository)(nil)
+
+type IdempotencyRepository struct {
+	db *sqlx.DB
+}
+
+func NewIdempotencyRepository(db *sqlx.DB) *IdempotencyRepository {
+	return &IdempotencyRepository{db: db}
+}
+
+func (r *IdempotencyRepository) Reserve(ctx context.Context, record domain.IdempotencyRecord) error {
+	const op = "idempotencyRepository.Reserve"
+
+	var q sqlx.ExecerContext = r.db
+	if tx, ok := txmanager.ExtractTx(ctx); ok {
+		q = tx
+	}
+
+	// Конкурентная вставка того же ключа ждёт завершения первой транзакции и затем пропускается
+	res, err := q.ExecContext(ctx, `
+		INSERT INTO idempotency_keys (user_id, key, fingerprint, order_uuid)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id, key) DO NOTHING
+	`, record.UserID, record.Key, record.Fingerprint, record.OrderUUID)
+	if err != nil {
+		return fmt.Errorf("%s: failed to insert idempotency key: %w", op, err)
+	}
+
+	count, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: failed to get affected rows: %w", op, err)
+	}
+
+	if count == 0 {
+		return fmt.Errorf("%s: %w", op, domain.ErrIdempotencyKeyExists)
+	}
+
+	return nil
+}
+
+func (r *IdempotencyRepository) Find(ctx context.Context, userID int64, key string) (domain.IdempotencyRecord, error) {
+	const op = "idempotencyRepository.Find"
+
+	var row dao.DBIdempotencyRecord
+	err := r.db.GetContext(ctx, &row, `
+		SELECT user_id, key, fingerprint, order_uuid, payment_url, created_at, completed_at
+		FROM idempotency_keys
+		WHERE user_id = $1 AND key = $2
+	`, userID, key)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.IdempotencyRecord{}, fmt.Errorf("%s: %w", op, domain.ErrIdempotencyKeyNotFound)
+		}
+		return domain.IdempotencyRecord{}, fmt.Errorf("%s: failed to fetch idempotency key: %w", op, err)
+	}
+
+	return dao.ToDomainIdempotencyRecord(row), nil
+}
+
+func (r *IdempotencyRepository) Complete(ctx context.Context, userID int64, key string, paymentURL string) error {
+	const op = "idempotencyRepository.Complete"
+
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE idempotency_keys
+		SET payment_url = $3, completed_at = now()
+		WHERE user_id = $1 AND key = $2
+	`, userID, key, nullableString(paymentURL))
+	if err != nil {
+		return fmt.Errorf("%s: failed to complete idempotency key: %w", op, err)
+	}
+
+	return nil
+}
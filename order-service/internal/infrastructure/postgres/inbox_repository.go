@@ -0,0 +1,58 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/Wrestler094/scalable-ecommerce-platform/order-service/internal/domain"
+	"github.com/Wrestler094/scalable-ecommerce-platform/order-service/internal/infrastructure/txmanager"
+)
+
+var _ domain.InboxRepository = (*InboxRepository)(nil)
+
+type InboxRepository struct {
+	db *sqlx.DB
+}
+
+func NewInboxRepository(db *sqlx.DB) *InboxRepository {
+	return &InboxRepository{db: db}
+}
+
+func (r *InboxRepository) MarkProcessed(ctx context.Context, eventID uuid.UUID, eventType string) error {
+	const op = "inboxRepository.MarkProcessed"
+	const query = `
+		INSERT INTO processed_events (event_id, event_type)
+		VALUES ($1, $2)
+		ON CONFLICT (event_id) DO NOTHING
+	`
+
+	var (
+		res sql.Result
+		err error
+	)
+
+	// Пытаемся получить транзакцию из контекста
+	if tx, ok := txmanager.ExtractTx(ctx); ok {
+		res, err = tx.ExecContext(ctx, query, eventID, eventType)
+	} else {
+		res, err = r.db.ExecContext(ctx, query, eventID, eventType)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: failed to insert processed event: %w", op, err)
+	}
+
+	count, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: failed to get affected rows: %w", op, err)
+	}
+
+	if count == 0 {
+		return fmt.Errorf("%s: event %s: %w", op, eventID, domain.ErrEventAlreadyProcessed)
+	}
+
+	return nil
+}
@@ -2,12 +2,18 @@ package postgres
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 
 	"github.com/Wrestler094/scalable-ecommerce-platform/order-service/internal/domain"
 	"github.com/Wrestler094/scalable-ecommerce-platform/order-service/internal/infrastructure/postgres/dao"
+	"github.com/Wrestler094/scalable-ecommerce-platform/order-service/internal/infrastructure/txmanager"
 )
 
 var _ domain.OrderRepository = (*OrderRepository)(nil)
@@ -24,33 +30,72 @@ func NewOrderRepository(db *sqlx.DB) *OrderRepository {
 func (r *OrderRepository) Create(ctx context.Context, order domain.Order) error {
 	const op = "orderRepository.Create"
 
+	// Внутри внешней транзакции (например, вместе с записью в outbox) используем её
+	if tx, ok := txmanager.ExtractTx(ctx); ok {
+		return r.create(ctx, tx, order)
+	}
+
 	tx, err := r.db.BeginTxx(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("%s: failed to begin transaction: %w", op, err)
 	}
 	defer func() { _ = tx.Rollback() }()
 
+	if err := r.create(ctx, tx, order); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("%s: failed to commit transaction: %w", op, err)
+	}
+
+	return nil
+}
+
+func (r *OrderRepository) create(ctx context.Context, tx *sqlx.Tx, order domain.Order) error {
+	const op = "orderRepository.create"
+
+	addr := order.Shipping.Address
+
 	var orderID int64
-	err = tx.QueryRowxContext(ctx, `
-		INSERT INTO orders (uuid, user_id, status, total_amount, created_at)
-		VALUES ($1, $2, $3, $4, $5)
+	err := tx.QueryRowxContext(ctx, `
+		INSERT INTO orders (
+			uuid, user_id, status, total_amount, created_at,
+			shipping_recipient, shipping_phone, shipping_country, shipping_city,
+			shipping_street, shipping_postal_code, delivery_method, shipping_cost
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
 		RETURNING id
-	`, order.UUID, order.UserID, order.Status, order.TotalAmount, order.CreatedAt).Scan(&orderID)
+	`, order.UUID, order.UserID, string(order.Status), order.TotalAmount, order.CreatedAt,
+		addr.Recipient, addr.Phone, addr.Country, addr.City,
+		addr.Street, nullableString(addr.PostalCode), string(order.Shipping.Method), order.Shipping.Cost,
+	).Scan(&orderID)
 	if err != nil {
 		return fmt.Errorf("%s: failed to insert order: %w", op, err)
 	}
 
 	items := dao.ToDBOrderItems(orderID, order.Items)
 	_, err = tx.NamedExecContext(ctx, `
-		INSERT INTO order_items (order_id, product_id, quantity, price)
-		VALUES (:order_id, :product_id, :quantity, :price)
+		INSERT INTO order_items (
+			order_id, product_id, quantity, price,
+			product_name, sku, category_id, category_name, tax_class
+		)
+		VALUES (
+			:order_id, :product_id, :quantity, :price,
+			:product_name, :sku, :category_id, :category_name, :tax_class
+		)
 	`, items)
 	if err != nil {
 		return fmt.Errorf("%s: failed to insert order items: %w", op, err)
 	}
 
-	if err := tx.Commit(); err != nil {
-		return fmt.Errorf("%s: failed to commit transaction: %w", op, err)
+	// Первая запись истории фиксирует начальный статус заказа
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO order_status_history (order_id, to_status, actor, actor_id, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`, orderID, string(order.Status), string(domain.StatusActorUser), order.UserID, order.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("%s: failed to insert order status history: %w", op, err)
 	}
 
 	return nil
@@ -65,10 +110,15 @@ func (r *OrderRepository) FindByUUID(ctx context.Context, uuid string) (domain.O
 		HasItem bool `db:"has_item"`
 	}
 
-	err := r.db.SelectContext(ctx, &rows, `
+	err := sqlx.SelectContext(ctx, r.queryer(ctx), &rows, `
 		SELECT 
 			o.id, o.uuid, o.user_id, o.status, o.total_amount, o.created_at,
+			o.cancel_reason, o.cancelled_at, o.payment_failure_reason, o.refunded_amount,
+			o.shipping_recipient, o.shipping_phone, o.shipping_country, o.shipping_city,
+			o.shipping_street, o.shipping_postal_code, o.delivery_method, o.shipping_cost,
 			oi.order_id, oi.product_id, oi.quantity, oi.price,
+			oi.refunded_quantity, oi.refunded_amount,
+			oi.product_name, oi.sku, oi.category_id, oi.category_name, oi.tax_class,
 			oi.order_id IS NOT NULL as has_item
 		FROM orders o
 		LEFT JOIN order_items oi ON o.id = oi.order_id
@@ -81,14 +131,8 @@ func (r *OrderRepository) FindByUUID(ctx context.Context, uuid string) (domain.O
 		return domain.Order{}, fmt.Errorf("%s: failed to find order: %w", op, domain.ErrOrderNotFound)
 	}
 
-	order := domain.Order{
-		UUID:        rows[0].UUID,
-		UserID:      rows[0].UserID,
-		Status:      rows[0].Status,
-		TotalAmount: rows[0].TotalAmount,
-		CreatedAt:   rows[0].CreatedAt,
-		Items:       make([]domain.OrderItem, 0, len(rows)),
-	}
+	order := dao.ToDomainOrder(rows[0].DBOrder)
+	order.Items = make([]domain.OrderItem, 0, len(rows))
 
 	for _, row := range rows {
 		if row.HasItem {
@@ -99,76 +143,289 @@ func (r *OrderRepository) FindByUUID(ctx context.Context, uuid string) (domain.O
 	return order, nil
 }
 
-func (r *OrderRepository) FindByUserID(ctx context.Context, userID int64) ([]domain.Order, error) {
-	const op = "orderRepository.FindByUserID"
+func (r *OrderRepository) List(ctx context.Context, filter domain.OrderListFilter) ([]domain.Order, error) {
+	const op = "orderRepository.List"
 
-	var rows []struct {
-		dao.DBOrder
-		dao.DBOrderItem
-		HasItem bool `db:"has_item"`
+	var (
+		conditions []string
+		args       []any
+	)
+
+	addCondition := func(format string, values ...any) {
+		placeholders := make([]any, len(values))
+		for i, v := range values {
+			args = append(args, v)
+			placeholders[i] = fmt.Sprintf("$%d", len(args))
+		}
+		conditions = append(conditions, fmt.Sprintf(format, placeholders...))
 	}
 
-	err := r.db.SelectContext(ctx, &rows, `
-		SELECT 
-			o.id, o.uuid, o.user_id, o.status, o.total_amount, o.created_at,
-			oi.order_id, oi.product_id, oi.quantity, oi.price,
-			oi.order_id IS NOT NULL as has_item
-		FROM orders o
-		LEFT JOIN order_items oi ON o.id = oi.order_id
-		WHERE o.user_id = $1
-		ORDER BY o.created_at DESC
-	`, userID)
+	if filter.UserID > 0 {
+		addCondition("user_id = %s", filter.UserID)
+	}
+	if filter.Status != "" {
+		addCondition("status = %s", string(filter.Status))
+	}
+	if filter.CreatedFrom != nil {
+		addCondition("created_at >= %s", *filter.CreatedFrom)
+	}
+	if filter.CreatedTo != nil {
+		addCondition("created_at < %s", *filter.CreatedTo)
+	}
+	if filter.MinAmount != nil {
+		addCondition("total_amount >= %s", *filter.MinAmount)
+	}
+	if filter.MaxAmount != nil {
+		addCondition("total_amount <= %s", *filter.MaxAmount)
+	}
+	if filter.Cursor != nil {
+		addCondition("(created_at, id) < (%s, %s)", filter.Cursor.CreatedAt, filter.Cursor.ID)
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	args = append(args, filter.Limit)
+	query := fmt.Sprintf(`
+		SELECT
+			id, uuid, user_id, status, total_amount, created_at,
+			cancel_reason, cancelled_at, payment_failure_reason, refunded_amount,
+			shipping_recipient, shipping_phone, shipping_country, shipping_city,
+			shipping_street, shipping_postal_code, delivery_method, shipping_cost
+		FROM orders
+		%s
+		ORDER BY created_at DESC, id DESC
+		LIMIT $%d
+	`, where, len(args))
+
+	q := r.queryer(ctx)
+
+	var dbOrders []dao.DBOrder
+	if err := sqlx.SelectContext(ctx, q, &dbOrders, query, args...); err != nil {
+		return nil, fmt.Errorf("%s: failed to fetch orders: %w", op, err)
+	}
+
+	orders, err := r.withItems(ctx, q, dbOrders)
 	if err != nil {
-		return nil, fmt.Errorf("%s: failed to fetch user orders: %w", op, err)
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	orderMap := make(map[string]*domain.Order)
-	for _, row := range rows {
-		order, exists := orderMap[row.DBOrder.UUID]
-		if !exists {
-			order = &domain.Order{
-				UUID:        row.UUID,
-				UserID:      row.UserID,
-				Status:      row.Status,
-				TotalAmount: row.TotalAmount,
-				CreatedAt:   row.CreatedAt,
-				Items:       []domain.OrderItem{},
-			}
-			orderMap[row.UUID] = order
-		}
+	return orders, nil
+}
 
-		if row.HasItem {
-			order.Items = append(order.Items, dao.ToDomainOrderItem(row.DBOrderItem))
-		}
+// FindUnpaidForUpdate блокирует неоплаченные заказы, созданные раньше before.
+// Строки, уже заблокированные другой репликой, пропускаются (SKIP LOCKED).
+// Требует транзакции в контексте: блокировки держатся до её завершения.
+func (r *OrderRepository) FindUnpaidForUpdate(ctx context.Context, before time.Time, limit int) ([]domain.Order, error) {
+	const op = "orderRepository.FindUnpaidForUpdate"
+
+	tx, ok := txmanager.ExtractTx(ctx)
+	if !ok {
+		return nil, fmt.Errorf("%s: transaction is required", op)
+	}
+
+	statuses := make([]string, 0, len(domain.AwaitingPaymentStatuses))
+	for _, s := range domain.AwaitingPaymentStatuses {
+		statuses = append(statuses, string(s))
+	}
+
+	var dbOrders []dao.DBOrder
+	err := tx.SelectContext(ctx, &dbOrders, `
+		SELECT
+			id, uuid, user_id, status, total_amount, created_at,
+			cancel_reason, cancelled_at, payment_failure_reason, refunded_amount,
+			shipping_recipient, shipping_phone, shipping_country, shipping_city,
+			shipping_street, shipping_postal_code, delivery_method, shipping_cost
+		FROM orders
+		WHERE status = ANY($1) AND created_at < $2
+		ORDER BY created_at ASC
+		LIMIT $3
+		FOR UPDATE SKIP LOCKED
+	`, pq.Array(statuses), before, limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to fetch unpaid orders: %w", op, err)
+	}
+
+	orders, err := r.withItems(ctx, tx, dbOrders)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return orders, nil
+}
+
+// withItems догружает позиции заказов, сохраняя порядок dbOrders.
+func (r *OrderRepository) withItems(ctx context.Context, q sqlx.QueryerContext, dbOrders []dao.DBOrder) ([]domain.Order, error) {
+	if len(dbOrders) == 0 {
+		return []domain.Order{}, nil
+	}
+
+	orderIDs := make([]int64, len(dbOrders))
+	for i, o := range dbOrders {
+		orderIDs[i] = o.ID
+	}
+
+	var dbItems []dao.DBOrderItem
+	err := sqlx.SelectContext(ctx, q, &dbItems, `
+		SELECT order_id, product_id, quantity, price, refunded_quantity, refunded_amount,
+			product_name, sku, category_id, category_name, tax_class
+		FROM order_items
+		WHERE order_id = ANY($1)
+	`, pq.Array(orderIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch order items: %w", err)
+	}
+
+	itemsByOrder := make(map[int64][]domain.OrderItem, len(dbOrders))
+	for _, item := range dbItems {
+		itemsByOrder[item.OrderID] = append(itemsByOrder[item.OrderID], dao.ToDomainOrderItem(item))
 	}
 
-	orders := make([]domain.Order, 0, len(orderMap))
-	for _, order := range orderMap {
-		orders = append(orders, *order)
+	// Порядок заказов задаёт SQL-запрос, items только дополняют его
+	orders := make([]domain.Order, len(dbOrders))
+	for i, o := range dbOrders {
+		orders[i] = dao.ToDomainOrder(o)
+		if items, ok := itemsByOrder[o.ID]; ok {
+			orders[i].Items = items
+		}
 	}
 
 	return orders, nil
 }
 
-func (r *OrderRepository) MarkAsPaid(ctx context.Context, uuid string) error {
-	const op = "orderRepository.MarkAsPaid"
+func (r *OrderRepository) UpdateStatus(
+	ctx context.Context,
+	order domain.Order,
+	from domain.OrderStatus,
+	change domain.StatusChange,
+) (time.Time, error) {
+	const op = "orderRepository.UpdateStatus"
+
+	q := r.queryer(ctx)
+
+	// Статус и запись истории сохраняются одним запросом, поэтому атомарны и вне транзакции
+	var changedAt time.Time
+	err := sqlx.GetContext(ctx, q, &changedAt, `
+		WITH updated AS (
+			UPDATE orders
+			SET status = $3, cancel_reason = $4, cancelled_at = $5, payment_failure_reason = $6
+			WHERE uuid = $1 AND status = $2
+			RETURNING id
+		)
+		INSERT INTO order_status_history (order_id, from_status, to_status, actor, actor_id, reason, event_id)
+		SELECT id, $2, $3, $7::text, $8::bigint, $9::text, $10::uuid FROM updated
+		RETURNING created_at
+	`, order.UUID, string(from), string(order.Status),
+		nullableString(order.CancelReason), order.CancelledAt, nullableString(order.PaymentFailureReason),
+		string(change.Actor), nullableInt64(change.ActorID), nullableString(change.Reason), change.EventID)
+	if err == nil {
+		return changedAt.UTC(), nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return time.Time{}, fmt.Errorf("%s: failed to update order status: %w", op, err)
+	}
+
+	// Ни одна строка не обновлена: либо заказа нет, либо его статус уже изменился
+	var exists bool
+	err = sqlx.GetContext(ctx, q, &exists, `SELECT EXISTS (SELECT 1 FROM orders WHERE uuid = $1)`, order.UUID)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s: failed to check order existence: %w", op, err)
+	}
+
+	if !exists {
+		return time.Time{}, fmt.Errorf("%s: failed to update order status: %w", op, domain.ErrOrderNotFound)
+	}
+
+	return time.Time{}, fmt.Errorf("%s: failed to update order status: %w", op, domain.ErrOrderStatusConflict)
+}
+
+func (r *OrderRepository) UpdateRefundedAmount(ctx context.Context, orderUUID string, amount float64) error {
+	const op = "orderRepository.UpdateRefundedAmount"
 
-	res, err := r.db.ExecContext(ctx, `
-		UPDATE orders SET status = 'paid'
+	res, err := r.queryer(ctx).ExecContext(ctx, `
+		UPDATE orders
+		SET refunded_amount = GREATEST(refunded_amount, $2)
 		WHERE uuid = $1
-	`, uuid)
+	`, orderUUID, amount)
 	if err != nil {
-		return fmt.Errorf("%s: failed to mark order as paid: %w", op, err)
+		return fmt.Errorf("%s: failed to update refunded amount: %w", op, err)
 	}
 
 	count, err := res.RowsAffected()
 	if err != nil {
 		return fmt.Errorf("%s: failed to get affected rows: %w", op, err)
 	}
-
 	if count == 0 {
-		return fmt.Errorf("%s: failed to mark order as paid: %w", op, domain.ErrOrderNotFound)
+		return fmt.Errorf("%s: %w", op, domain.ErrOrderNotFound)
+	}
+
+	return nil
+}
+
+// UpdateItemRefunds обновляет позиции по товару: у товара в заказе одна строка,
+// это гарантирует ключ order_items (order_id, product_id).
+func (r *OrderRepository) UpdateItemRefunds(ctx context.Context, order domain.Order) error {
+	const op = "orderRepository.UpdateItemRefunds"
+
+	q := r.queryer(ctx)
+	for _, item := range order.Items {
+		_, err := q.ExecContext(ctx, `
+			UPDATE order_items
+			SET refunded_quantity = $3, refunded_amount = $4
+			WHERE order_id = $1 AND product_id = $2
+		`, order.ID, item.ProductID, item.RefundedQuantity, item.RefundedAmount)
+		if err != nil {
+			return fmt.Errorf("%s: failed to update order item %d: %w", op, item.ProductID, err)
+		}
 	}
 
 	return nil
 }
+
+func (r *OrderRepository) ListStatusHistory(ctx context.Context, orderID int64) ([]domain.StatusHistoryEntry, error) {
+	const op = "orderRepository.ListStatusHistory"
+
+	var rows []dao.DBStatusHistoryEntry
+	err := sqlx.SelectContext(ctx, r.queryer(ctx), &rows, `
+		SELECT from_status, to_status, actor, actor_id, reason, event_id, created_at
+		FROM order_status_history
+		WHERE order_id = $1
+		ORDER BY id
+	`, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to fetch order status history: %w", op, err)
+	}
+
+	history := make([]domain.StatusHistoryEntry, len(rows))
+	for i, row := range rows {
+		history[i] = dao.ToDomainStatusHistoryEntry(row)
+	}
+
+	return history, nil
+}
+
+// queryer возвращает транзакцию из контекста, если она есть, иначе — обычное соединение.
+func (r *OrderRepository) queryer(ctx context.Context) sqlx.ExtContext {
+	if tx, ok := txmanager.ExtractTx(ctx); ok {
+		return tx
+	}
+	return r.db
+}
+
+// nullableString превращает пустую строку в NULL.
+func nullableString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// nullableInt64 превращает нулевое значение в NULL.
+func nullableInt64(v int64) *int64 {
+	if v == 0 {
+		return nil
+	}
+	return &v
+}
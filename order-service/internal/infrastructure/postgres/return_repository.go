@@ -0,0 +1,180 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/Wrestler094/scalable-ecommerce-platform/order-service/internal/domain"
+	"github.com/Wrestler094/scalable-ecommerce-platform/order-service/internal/infrastructure/postgres/dao"
+	"github.com/Wrestler094/scalable-ecommerce-platform/order-service/internal/infrastructure/txmanager"
+)
+
+var _ domain.ReturnRepository = (*ReturnRepository)(nil)
+
+const pgErrCodeUniqueViolation = "23505"
+
+const selectOrderReturns = `
+	SELECT
+		r.id, r.uuid, o.uuid AS order_uuid, r.user_id, r.status, r.reason, r.refund_amount, r.created_at,
+		r.resolved_by, r.resolution_comment, r.resolved_at
+	FROM order_returns r
+	JOIN orders o ON o.id = r.order_id
+`
+
+type ReturnRepository struct {
+	db *sqlx.DB
+}
+
+func NewReturnRepository(db *sqlx.DB) *ReturnRepository {
+	return &ReturnRepository{db: db}
+}
+
+func (r *ReturnRepository) Create(ctx context.Context, ret domain.OrderReturn) error {
+	const op = "returnRepository.Create"
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var returnID int64
+	err = tx.QueryRowxContext(ctx, `
+		INSERT INTO order_returns (uuid, order_id, user_id, status, reason, refund_amount, created_at)
+		SELECT $1::uuid, id, $3::bigint, $4::text, $5::text, $6::numeric, $7::timestamptz FROM orders WHERE uuid = $2
+		RETURNING id
+	`, ret.UUID, ret.OrderUUID, ret.UserID, string(ret.Status), ret.Reason, ret.RefundAmount, ret.CreatedAt).Scan(&returnID)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == pgErrCodeUniqueViolation {
+			return fmt.Errorf("%s: %w", op, domain.ErrReturnAlreadyRequested)
+		}
+		return fmt.Errorf("%s: failed to insert return: %w", op, err)
+	}
+
+	_, err = tx.NamedExecContext(ctx, `
+		INSERT INTO order_return_items (return_id, product_id, quantity, amount)
+		VALUES (:return_id, :product_id, :quantity, :amount)
+	`, dao.ToDBOrderReturnItems(returnID, ret.Items))
+	if err != nil {
+		return fmt.Errorf("%s: failed to insert return items: %w", op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("%s: failed to commit transaction: %w", op, err)
+	}
+
+	return nil
+}
+
+func (r *ReturnRepository) FindByUUID(ctx context.Context, uuid string) (domain.OrderReturn, error) {
+	const op = "returnRepository.FindByUUID"
+
+	returns, err := r.selectReturns(ctx, selectOrderReturns+`WHERE r.uuid = $1`, uuid)
+	if err != nil {
+		return domain.OrderReturn{}, fmt.Errorf("%s: %w", op, err)
+	}
+	if len(returns) == 0 {
+		return domain.OrderReturn{}, fmt.Errorf("%s: %w", op, domain.ErrReturnNotFound)
+	}
+
+	return returns[0], nil
+}
+
+func (r *ReturnRepository) ListByOrder(ctx context.Context, orderUUID string) ([]domain.OrderReturn, error) {
+	const op = "returnRepository.ListByOrder"
+
+	returns, err := r.selectReturns(ctx, selectOrderReturns+`WHERE o.uuid = $1 ORDER BY r.created_at, r.id`, orderUUID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return returns, nil
+}
+
+func (r *ReturnRepository) ListByStatus(ctx context.Context, status domain.ReturnStatus, limit int) ([]domain.OrderReturn, error) {
+	const op = "returnRepository.ListByStatus"
+
+	returns, err := r.selectReturns(ctx, selectOrderReturns+`WHERE r.status = $1 ORDER BY r.created_at, r.id LIMIT $2`, string(status), limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return returns, nil
+}
+
+func (r *ReturnRepository) Resolve(ctx context.Context, ret domain.OrderReturn) error {
+	const op = "returnRepository.Resolve"
+
+	var q sqlx.ExecerContext = r.db
+	if tx, ok := txmanager.ExtractTx(ctx); ok {
+		q = tx
+	}
+
+	// Условие на статус защищает от повторного одобрения одной и той же заявки
+	res, err := q.ExecContext(ctx, `
+		UPDATE order_returns
+		SET status = $2, resolved_by = $3, resolution_comment = $4, resolved_at = $5
+		WHERE uuid = $1 AND status = $6
+	`, ret.UUID, string(ret.Status), ret.ResolvedBy, nullableString(ret.ResolutionComment), ret.ResolvedAt,
+		string(domain.ReturnStatusRequested))
+	if err != nil {
+		return fmt.Errorf("%s: failed to update return: %w", op, err)
+	}
+
+	count, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: failed to get affected rows: %w", op, err)
+	}
+
+	if count == 0 {
+		return fmt.Errorf("%s: %w", op, domain.ErrReturnAlreadyResolved)
+	}
+
+	return nil
+}
+
+// selectReturns выполняет запрос к order_returns и догружает позиции заявок.
+func (r *ReturnRepository) selectReturns(ctx context.Context, query string, args ...any) ([]domain.OrderReturn, error) {
+	var dbReturns []dao.DBOrderReturn
+	if err := r.db.SelectContext(ctx, &dbReturns, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to fetch returns: %w", err)
+	}
+	if len(dbReturns) == 0 {
+		return []domain.OrderReturn{}, nil
+	}
+
+	returnIDs := make([]int64, len(dbReturns))
+	for i, ret := range dbReturns {
+		returnIDs[i] = ret.ID
+	}
+
+	var dbItems []dao.DBOrderReturnItem
+	err := r.db.SelectContext(ctx, &dbItems, `
+		SELECT return_id, product_id, quantity, amount
+		FROM order_return_items
+		WHERE return_id = ANY($1)
+	`, pq.Array(returnIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch return items: %w", err)
+	}
+
+	itemsByReturn := make(map[int64][]domain.ReturnItem, len(dbReturns))
+	for _, item := range dbItems {
+		itemsByReturn[item.ReturnID] = append(itemsByReturn[item.ReturnID], dao.ToDomainReturnItem(item))
+	}
+
+	returns := make([]domain.OrderReturn, len(dbReturns))
+	for i, ret := range dbReturns {
+		returns[i] = dao.ToDomainOrderReturn(ret)
+		if items, ok := itemsByReturn[ret.ID]; ok {
+			returns[i].Items = items
+		}
+	}
+
+	return returns, nil
+}
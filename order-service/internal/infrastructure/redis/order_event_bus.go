@@ -0,0 +1,141 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/Wrestler094/scalable-ecommerce-platform/pkg/logger"
+
+	"github.com/Wrestler094/scalable-ecommerce-platform/order-service/internal/domain"
+)
+
+var (
+	_ domain.OrderEventPublisher  = (*OrderEventBus)(nil)
+	_ domain.OrderEventSubscriber = (*OrderEventBus)(nil)
+)
+
+// subscriptionBufferSize — сколько событий подписки может ждать медленного клиента
+const subscriptionBufferSize = 16
+
+// OrderEventBus раздаёт смену статусов заказов всем репликам через Redis pub/sub.
+// У каждого заказа свой канал, поэтому реплика получает только события заказов,
+// на которые подписаны её клиенты.
+type OrderEventBus struct {
+	client *redis.Client
+	prefix string
+	logger logger.Logger
+}
+
+func NewOrderEventBus(client *redis.Client, logger logger.Logger) *OrderEventBus {
+	return &OrderEventBus{
+		client: client,
+		prefix: "ORDER_EVENTS",
+		logger: logger,
+	}
+}
+
+type orderStatusMessage struct {
+	OrderUUID  string    `json:"order_uuid"`
+	UserID     int64     `json:"user_id"`
+	FromStatus string    `json:"from_status"`
+	Status     string    `json:"status"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+func (b *OrderEventBus) channel(orderUUID string) string {
+	return fmt.Sprintf("%s:%s", b.prefix, orderUUID)
+}
+
+func (b *OrderEventBus) PublishStatusChanged(ctx context.Context, event domain.OrderStatusEvent) {
+	const op = "orderEventBus.PublishStatusChanged"
+
+	log := b.logger.WithOp(op).With("order_uuid", event.OrderUUID, "status", event.Status)
+
+	payload, err := json.Marshal(orderStatusMessage{
+		OrderUUID:  event.OrderUUID,
+		UserID:     event.UserID,
+		FromStatus: string(event.FromStatus),
+		Status:     string(event.Status),
+		OccurredAt: event.OccurredAt,
+	})
+	if err != nil {
+		log.WithError(err).Error("failed to marshal order status event")
+		return
+	}
+
+	if err := b.client.Publish(ctx, b.channel(event.OrderUUID), payload).Err(); err != nil {
+		log.WithError(err).Warn("failed to publish order status event")
+	}
+}
+
+func (b *OrderEventBus) SubscribeStatusChanges(ctx context.Context, orderUUID string) (domain.OrderStatusSubscription, error) {
+	const op = "orderEventBus.SubscribeStatusChanges"
+
+	pubsub := b.client.Subscribe(ctx, b.channel(orderUUID))
+
+	// Receive дожидается подтверждения подписки, иначе ранние события можно потерять
+	if _, err := pubsub.Receive(ctx); err != nil {
+		_ = pubsub.Close()
+		return nil, fmt.Errorf("%s: failed to subscribe: %w", op, err)
+	}
+
+	sub := &orderStatusSubscription{
+		pubsub: pubsub,
+		events: make(chan domain.OrderStatusEvent, subscriptionBufferSize),
+		done:   make(chan struct{}),
+	}
+	go sub.run(b.logger.WithOp(op).With("order_uuid", orderUUID))
+
+	return sub, nil
+}
+
+type orderStatusSubscription struct {
+	pubsub    *redis.PubSub
+	events    chan domain.OrderStatusEvent
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+func (s *orderStatusSubscription) Events() <-chan domain.OrderStatusEvent {
+	return s.events
+}
+
+func (s *orderStatusSubscription) Close() error {
+	var err error
+	s.closeOnce.Do(func() {
+		close(s.done)
+		err = s.pubsub.Close()
+	})
+	return err
+}
+
+func (s *orderStatusSubscription) run(log logger.Logger) {
+	defer close(s.events)
+
+	for msg := range s.pubsub.Channel() {
+		var m orderStatusMessage
+		if err := json.Unmarshal([]byte(msg.Payload), &m); err != nil {
+			log.WithError(err).Warn("failed to unmarshal order status event")
+			continue
+		}
+
+		event := domain.OrderStatusEvent{
+			OrderUUID:  m.OrderUUID,
+			UserID:     m.UserID,
+			FromStatus: domain.OrderStatus(m.FromStatus),
+			Status:     domain.OrderStatus(m.Status),
+			OccurredAt: m.OccurredAt,
+		}
+
+		select {
+		case s.events <- event:
+		case <-s.done:
+			return
+		}
+	}
+}
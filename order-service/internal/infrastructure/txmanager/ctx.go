@@ -0,0 +1,34 @@
+package txmanager
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type ctxKeyTx struct{}
+
+func InjectTx(ctx context.Context, tx *sqlx.Tx) context.Context {
+	return context.WithValue(ctx, ctxKeyTx{}, tx)
+}
+
+func ExtractTx(ctx context.Context) (*sqlx.Tx, bool) {
+	tx, ok := ctx.Value(ctxKeyTx{}).(*sqlx.Tx)
+	return tx, ok
+}
+
+type ctxKeyAfterCommit struct{}
+
+// afterCommitHooks — действия, отложенные до успешной фиксации транзакции
+type afterCommitHooks struct {
+	fns []func(ctx context.Context)
+}
+
+func injectAfterCommitHooks(ctx context.Context, hooks *afterCommitHooks) context.Context {
+	return context.WithValue(ctx, ctxKeyAfterCommit{}, hooks)
+}
+
+func extractAfterCommitHooks(ctx context.Context) (*afterCommitHooks, bool) {
+	hooks, ok := ctx.Value(ctxKeyAfterCommit{}).(*afterCommitHooks)
+	return hooks, ok
+}
@@ -0,0 +1,131 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Wrestler094/scalable-ecommerce-platform/pkg/events"
+	"github.com/Wrestler094/scalable-ecommerce-platform/pkg/outbox"
+
+	"github.com/Wrestler094/scalable-ecommerce-platform/order-service/internal/domain"
+)
+
+var _ domain.AdminOrderUseCase = (*AdminOrderUseCase)(nil)
+
+type AdminOrderUseCase struct {
+	orderRepo    domain.OrderRepository
+	outboxWriter outbox.Writer
+	txManager    domain.TxManager
+	notifier     statusNotifier
+}
+
+func NewAdminOrderUseCase(
+	orderRepo domain.OrderRepository,
+	outboxWriter outbox.Writer,
+	txManager domain.TxManager,
+	events domain.OrderEventPublisher,
+) *AdminOrderUseCase {
+	return &AdminOrderUseCase{
+		orderRepo:    orderRepo,
+		outboxWriter: outboxWriter,
+		txManager:    txManager,
+		notifier:     newStatusNotifier(txManager, events),
+	}
+}
+
+func (u *AdminOrderUseCase) ListOrders(ctx context.Context, filter domain.OrderListFilter) (domain.OrderPage, error) {
+	const op = "adminOrderUseCase.ListOrders"
+
+	page, err := listOrders(ctx, u.orderRepo, filter)
+	if err != nil {
+		return domain.OrderPage{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return page, nil
+}
+
+func (u *AdminOrderUseCase) GetOrder(ctx context.Context, orderUUID string) (domain.Order, error) {
+	return u.orderRepo.FindByUUID(ctx, orderUUID)
+}
+
+// ChangeOrderStatus переводит заказ в новый статус от имени администратора.
+// Переход проверяется тем же автоматом статусов, что и для остальных сценариев.
+func (u *AdminOrderUseCase) ChangeOrderStatus(ctx context.Context, cmd domain.ChangeOrderStatusCommand) (domain.Order, error) {
+	const op = "adminOrderUseCase.ChangeOrderStatus"
+
+	if !cmd.Status.IsValid() {
+		return domain.Order{}, fmt.Errorf("%s: unknown status %q: %w", op, cmd.Status, domain.ErrInvalidOrderStatus)
+	}
+
+	comment := strings.TrimSpace(cmd.Comment)
+	if comment == "" {
+		return domain.Order{}, fmt.Errorf("%s: %w", op, domain.ErrStatusCommentRequired)
+	}
+
+	var (
+		order   domain.Order
+		from    domain.OrderStatus
+		wasPaid bool
+	)
+	err := u.txManager.WithinTx(ctx, func(txCtx context.Context) error {
+		now := time.Now().UTC()
+
+		var err error
+		change := domain.AdminStatusChange(cmd.AdminID, comment)
+		order, err = transitionOrder(txCtx, u.orderRepo, u.notifier, cmd.OrderUUID, change, func(order *domain.Order) error {
+			from = order.Status
+			wasPaid = order.Status.IsPaid()
+
+			if cmd.Status == domain.OrderStatusCancelled {
+				return order.Cancel(comment, now)
+			}
+			return order.TransitionTo(cmd.Status)
+		})
+		if err != nil {
+			return fmt.Errorf("%s: failed to change order status: %w", op, err)
+		}
+
+		err = writeOutboxEvent(txCtx, u.outboxWriter, events.TopicOrders, events.EventOrderStatusChanged, now, events.OrderStatusChangedPayload{
+			OrderUUID:  order.UUID,
+			UserID:     order.UserID,
+			FromStatus: string(from),
+			ToStatus:   string(order.Status),
+			Comment:    comment,
+			ChangedBy:  cmd.AdminID,
+		})
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		// Потребители order_paid и order_cancelled не должны отличать ручную смену статуса от обычной
+		switch order.Status {
+		case domain.OrderStatusPaid:
+			err = writeOutboxEvent(txCtx, u.outboxWriter, events.TopicOrders, events.EventOrderPaid, now, events.OrderPaidPayload{
+				OrderUUID: order.UUID,
+				UserID:    order.UserID,
+				Amount:    order.TotalAmount,
+			})
+		case domain.OrderStatusCancelled:
+			err = writeOutboxEvent(txCtx, u.outboxWriter, events.TopicOrders, events.EventOrderCancelled, now, events.OrderCancelledPayload{
+				OrderUUID:      order.UUID,
+				UserID:         order.UserID,
+				Amount:         order.TotalAmount,
+				Reason:         comment,
+				CancelledBy:    "admin",
+				RefundRequired: wasPaid,
+			})
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return domain.Order{}, err
+	}
+
+	return order, nil
+}
@@ -0,0 +1,156 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Wrestler094/scalable-ecommerce-platform/pkg/events"
+	"github.com/Wrestler094/scalable-ecommerce-platform/pkg/outbox"
+
+	"github.com/Wrestler094/scalable-ecommerce-platform/order-service/internal/domain"
+)
+
+var _ domain.AdminReturnUseCase = (*AdminReturnUseCase)(nil)
+
+type AdminReturnUseCase struct {
+	orderRepo    domain.OrderRepository
+	returnRepo   domain.ReturnRepository
+	outboxWriter outbox.Writer
+	txManager    domain.TxManager
+	notifier     statusNotifier
+}
+
+func NewAdminReturnUseCase(
+	orderRepo domain.OrderRepository,
+	returnRepo domain.ReturnRepository,
+	outboxWriter outbox.Writer,
+	txManager domain.TxManager,
+	events domain.OrderEventPublisher,
+) *AdminReturnUseCase {
+	return &AdminReturnUseCase{
+		orderRepo:    orderRepo,
+		returnRepo:   returnRepo,
+		outboxWriter: outboxWriter,
+		txManager:    txManager,
+		notifier:     newStatusNotifier(txManager, events),
+	}
+}
+
+func (u *AdminReturnUseCase) ListReturns(ctx context.Context, status domain.ReturnStatus) ([]domain.OrderReturn, error) {
+	const op = "adminReturnUseCase.ListReturns"
+
+	if !status.IsValid() {
+		return nil, fmt.Errorf("%s: unknown status %q: %w", op, status, domain.ErrInvalidReturnStatus)
+	}
+
+	returns, err := u.returnRepo.ListByStatus(ctx, status, domain.MaxReturnListLimit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return returns, nil
+}
+
+// ApproveReturn одобряет заявку, учитывает возвращённые позиции в заказе и пишет
+// в outbox событие order_return_approved, по которому payment-service возвращает средства.
+// Возврат последних позиций заказа возвращает и стоимость доставки.
+func (u *AdminReturnUseCase) ApproveReturn(ctx context.Context, cmd domain.ResolveReturnCommand) (domain.OrderReturn, error) {
+	const op = "adminReturnUseCase.ApproveReturn"
+
+	var ret domain.OrderReturn
+	err := u.txManager.WithinTx(ctx, func(txCtx context.Context) error {
+		now := time.Now().UTC()
+
+		var err error
+		ret, err = u.resolve(txCtx, cmd, domain.ReturnStatusApproved, now)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		reason := "return approved"
+		if comment := strings.TrimSpace(cmd.Comment); comment != "" {
+			reason += ": " + comment
+		}
+
+		var amount float64
+		order, err := transitionOrder(txCtx, u.orderRepo, u.notifier, ret.OrderUUID, domain.AdminStatusChange(cmd.AdminID, reason), func(order *domain.Order) error {
+			var err error
+			amount, err = order.ApplyReturn(ret.Items)
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("%s: failed to apply return to order: %w", op, err)
+		}
+
+		if err := u.orderRepo.UpdateItemRefunds(txCtx, order); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		items := make([]events.OrderReturnItemPayload, len(ret.Items))
+		for i, item := range ret.Items {
+			items[i] = events.OrderReturnItemPayload{
+				ProductID: item.ProductID,
+				Quantity:  item.Quantity,
+				Amount:    item.Amount,
+			}
+		}
+
+		err = writeOutboxEvent(txCtx, u.outboxWriter, events.TopicOrders, events.EventOrderReturnApproved, now, events.OrderReturnApprovedPayload{
+			ReturnUUID:    ret.UUID,
+			OrderUUID:     order.UUID,
+			UserID:        order.UserID,
+			Amount:        amount,
+			Items:         items,
+			FullyRefunded: order.Status == domain.OrderStatusRefunded,
+		})
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return domain.OrderReturn{}, err
+	}
+
+	return ret, nil
+}
+
+func (u *AdminReturnUseCase) RejectReturn(ctx context.Context, cmd domain.ResolveReturnCommand) (domain.OrderReturn, error) {
+	const op = "adminReturnUseCase.RejectReturn"
+
+	if strings.TrimSpace(cmd.Comment) == "" {
+		return domain.OrderReturn{}, fmt.Errorf("%s: %w", op, domain.ErrStatusCommentRequired)
+	}
+
+	ret, err := u.resolve(ctx, cmd, domain.ReturnStatusRejected, time.Now().UTC())
+	if err != nil {
+		return domain.OrderReturn{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return ret, nil
+}
+
+func (u *AdminReturnUseCase) resolve(
+	ctx context.Context,
+	cmd domain.ResolveReturnCommand,
+	status domain.ReturnStatus,
+	at time.Time,
+) (domain.OrderReturn, error) {
+	ret, err := u.returnRepo.FindByUUID(ctx, cmd.ReturnUUID)
+	if err != nil {
+		return domain.OrderReturn{}, err
+	}
+
+	if err := ret.Resolve(status, cmd.AdminID, strings.TrimSpace(cmd.Comment), at); err != nil {
+		return domain.OrderReturn{}, err
+	}
+
+	if err := u.returnRepo.Resolve(ctx, ret); err != nil {
+		return domain.OrderReturn{}, err
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,80 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Wrestler094/scalable-ecommerce-platform/order-service/internal/domain"
+)
+
+var _ domain.OrderEventsUseCase = (*OrderEventsUseCase)(nil)
+
+type OrderEventsUseCase struct {
+	orderRepo  domain.OrderRepository
+	subscriber domain.OrderEventSubscriber
+}
+
+func NewOrderEventsUseCase(orderRepo domain.OrderRepository, subscriber domain.OrderEventSubscriber) *OrderEventsUseCase {
+	return &OrderEventsUseCase{
+		orderRepo:  orderRepo,
+		subscriber: subscriber,
+	}
+}
+
+// SubscribeStatusChanges подписывает пользователя на смену статусов его заказа.
+// Подписка оформляется до чтения заказа, поэтому переход, случившийся между чтением
+// и подпиской, не теряется: возвращённый заказ — начальное состояние для клиента.
+func (u *OrderEventsUseCase) SubscribeStatusChanges(
+	ctx context.Context,
+	userID int64,
+	isAdmin bool,
+	orderUUID string,
+) (domain.Order, domain.OrderStatusSubscription, error) {
+	const op = "orderEventsUseCase.SubscribeStatusChanges"
+
+	sub, err := u.subscriber.SubscribeStatusChanges(ctx, orderUUID)
+	if err != nil {
+		return domain.Order{}, nil, fmt.Errorf("%s: failed to subscribe: %w", op, err)
+	}
+
+	order, err := u.orderRepo.FindByUUID(ctx, orderUUID)
+	if err != nil {
+		_ = sub.Close()
+		return domain.Order{}, nil, fmt.Errorf("%s: failed to get order: %w", op, err)
+	}
+
+	if !isAdmin && order.UserID != userID {
+		_ = sub.Close()
+		return domain.Order{}, nil, fmt.Errorf("%s: %w", op, domain.ErrOrderAccessDenied)
+	}
+
+	return order, sub, nil
+}
+
+// statusNotifier публикует смену статуса заказа только после фиксации транзакции,
+// чтобы подписчики не увидели статус, который затем откатится.
+type statusNotifier struct {
+	txManager domain.TxManager
+	publisher domain.OrderEventPublisher
+}
+
+func newStatusNotifier(txManager domain.TxManager, publisher domain.OrderEventPublisher) statusNotifier {
+	return statusNotifier{txManager: txManager, publisher: publisher}
+}
+
+// statusChanged публикует переход со временем из истории статусов, чтобы событие
+// совпадало с записью, которую возвращает /orders/{id}/history.
+func (n statusNotifier) statusChanged(ctx context.Context, order domain.Order, from domain.OrderStatus, changedAt time.Time) {
+	event := domain.OrderStatusEvent{
+		OrderUUID:  order.UUID,
+		UserID:     order.UserID,
+		FromStatus: from,
+		Status:     order.Status,
+		OccurredAt: changedAt,
+	}
+
+	n.txManager.AfterCommit(ctx, func(ctx context.Context) {
+		n.publisher.PublishStatusChanged(ctx, event)
+	})
+}
@@ -0,0 +1,86 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Wrestler094/scalable-ecommerce-platform/pkg/events"
+	"github.com/Wrestler094/scalable-ecommerce-platform/pkg/outbox"
+
+	"github.com/Wrestler094/scalable-ecommerce-platform/order-service/internal/domain"
+)
+
+var _ domain.OrderExpiryUseCase = (*ExpiryUseCase)(nil)
+
+type ExpiryUseCase struct {
+	orderRepo    domain.OrderRepository
+	outboxWriter outbox.Writer
+	txManager    domain.TxManager
+	notifier     statusNotifier
+}
+
+func NewExpiryUseCase(
+	orderRepo domain.OrderRepository,
+	outboxWriter outbox.Writer,
+	txManager domain.TxManager,
+	events domain.OrderEventPublisher,
+) *ExpiryUseCase {
+	return &ExpiryUseCase{
+		orderRepo:    orderRepo,
+		outboxWriter: outboxWriter,
+		txManager:    txManager,
+		notifier:     newStatusNotifier(txManager, events),
+	}
+}
+
+// ExpireUnpaidOrders отменяет одну пачку просроченных заказов в одной транзакции.
+// Заказы блокируются через SKIP LOCKED, поэтому несколько реплик обрабатывают разные пачки.
+// По событию order_expired payment-service отменяет незавершённую оплату заказа.
+// Остатки товаров здесь не освобождаются: резервирования стока в системе пока нет,
+// событие несёт позиции заказа для будущего потребителя.
+func (u *ExpiryUseCase) ExpireUnpaidOrders(ctx context.Context, ttl time.Duration, limit int) (int, error) {
+	const op = "expiryUseCase.ExpireUnpaidOrders"
+
+	var expired int
+	err := u.txManager.WithinTx(ctx, func(txCtx context.Context) error {
+		now := time.Now().UTC()
+
+		orders, err := u.orderRepo.FindUnpaidForUpdate(txCtx, now.Add(-ttl), limit)
+		if err != nil {
+			return fmt.Errorf("%s: failed to find unpaid orders: %w", op, err)
+		}
+
+		for _, order := range orders {
+			from := order.Status
+			if err := order.Expire(now); err != nil {
+				return fmt.Errorf("%s: order %s: %w", op, order.UUID, err)
+			}
+
+			// Строка заблокирована в этой транзакции, поэтому конфликт статуса здесь невозможен
+			changedAt, err := u.orderRepo.UpdateStatus(txCtx, order, from, domain.SystemStatusChange(domain.ExpiredCancelReason))
+			if err != nil {
+				return fmt.Errorf("%s: failed to expire order %s: %w", op, order.UUID, err)
+			}
+			u.notifier.statusChanged(txCtx, order, from, changedAt)
+
+			err = writeOutboxEvent(txCtx, u.outboxWriter, events.TopicOrders, events.EventOrderExpired, now, events.OrderExpiredPayload{
+				OrderUUID: order.UUID,
+				UserID:    order.UserID,
+				Amount:    order.TotalAmount,
+				Items:     toEventItems(order.Items),
+			})
+			if err != nil {
+				return fmt.Errorf("%s: %w", op, err)
+			}
+		}
+
+		expired = len(orders)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return expired, nil
+}
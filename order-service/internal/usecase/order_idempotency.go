@@ -0,0 +1,61 @@
+package usecase
+
+import (
+	"cmp"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/Wrestler094/scalable-ecommerce-platform/order-service/internal/domain"
+)
+
+// idempotencyInProgressTimeout — сколько незавершённый запрос считается выполняющимся.
+// После этого повтор возвращает сохранённый заказ без ссылки на оплату, её можно получить через RetryPayment.
+const idempotencyInProgressTimeout = time.Minute
+
+// replayOrder возвращает результат исходного запроса с тем же ключом идемпотентности.
+func (s *OrderUseCase) replayOrder(ctx context.Context, key domain.IdempotencyRecord) (domain.Order, string, error) {
+	const op = "orderUseCase.replayOrder"
+
+	record, err := s.idempotencyRepo.Find(ctx, key.UserID, key.Key)
+	if err != nil {
+		return domain.Order{}, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	if record.Fingerprint != key.Fingerprint {
+		return domain.Order{}, "", fmt.Errorf("%s: %w", op, domain.ErrIdempotencyKeyReused)
+	}
+
+	if record.CompletedAt == nil && time.Since(record.CreatedAt) < idempotencyInProgressTimeout {
+		return domain.Order{}, "", fmt.Errorf("%s: %w", op, domain.ErrIdempotencyRequestInProgress)
+	}
+
+	order, err := s.orderRepo.FindByUUID(ctx, record.OrderUUID)
+	if err != nil {
+		return domain.Order{}, "", fmt.Errorf("%s: failed to get order: %w", op, err)
+	}
+
+	return order, record.PaymentURL, nil
+}
+
+// fingerprintOrder вычисляет хеш запроса на создание заказа, не зависящий от порядка позиций.
+func fingerprintOrder(items []domain.OrderItemInput, shipping domain.ShippingInput) string {
+	sorted := slices.Clone(items)
+	slices.SortFunc(sorted, func(a, b domain.OrderItemInput) int {
+		return cmp.Or(cmp.Compare(a.ProductID, b.ProductID), cmp.Compare(a.Quantity, b.Quantity))
+	})
+
+	h := sha256.New()
+	for _, item := range sorted {
+		_, _ = fmt.Fprintf(h, "%d:%d;", item.ProductID, item.Quantity)
+	}
+
+	addr := shipping.Address
+	_, _ = fmt.Fprintf(h, "%q|%q|%q|%q|%q|%q|%q",
+		shipping.Method, addr.Recipient, addr.Phone, addr.Country, addr.City, addr.Street, addr.PostalCode)
+
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,48 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Wrestler094/scalable-ecommerce-platform/order-service/internal/domain"
+)
+
+// listOrders проверяет фильтр и возвращает страницу заказов с курсором на следующую.
+func listOrders(ctx context.Context, repo domain.OrderRepository, filter domain.OrderListFilter) (domain.OrderPage, error) {
+	const op = "orderUseCase.listOrders"
+
+	if filter.Status != "" && !filter.Status.IsValid() {
+		return domain.OrderPage{}, fmt.Errorf("%s: unknown status %q: %w", op, filter.Status, domain.ErrInvalidOrderFilter)
+	}
+	if filter.CreatedFrom != nil && filter.CreatedTo != nil && filter.CreatedFrom.After(*filter.CreatedTo) {
+		return domain.OrderPage{}, fmt.Errorf("%s: created_from is after created_to: %w", op, domain.ErrInvalidOrderFilter)
+	}
+	if filter.MinAmount != nil && filter.MaxAmount != nil && *filter.MinAmount > *filter.MaxAmount {
+		return domain.OrderPage{}, fmt.Errorf("%s: min_amount is greater than max_amount: %w", op, domain.ErrInvalidOrderFilter)
+	}
+
+	switch {
+	case filter.Limit <= 0:
+		filter.Limit = domain.DefaultOrderListLimit
+	case filter.Limit > domain.MaxOrderListLimit:
+		filter.Limit = domain.MaxOrderListLimit
+	}
+
+	// Запрашиваем на один заказ больше, чтобы понять, есть ли следующая страница
+	pageLimit := filter.Limit
+	filter.Limit++
+
+	orders, err := repo.List(ctx, filter)
+	if err != nil {
+		return domain.OrderPage{}, fmt.Errorf("%s: failed to list orders: %w", op, err)
+	}
+
+	page := domain.OrderPage{Orders: orders}
+	if len(orders) > pageLimit {
+		page.Orders = orders[:pageLimit]
+		last := page.Orders[pageLimit-1]
+		page.NextCursor = &domain.OrderCursor{CreatedAt: last.CreatedAt, ID: last.ID}
+	}
+
+	return page, nil
+}
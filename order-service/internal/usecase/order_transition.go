@@ -0,0 +1,55 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Wrestler094/scalable-ecommerce-platform/order-service/internal/domain"
+)
+
+// maxStatusUpdateAttempts — число попыток применить переход статуса при конкурентном изменении заказа.
+const maxStatusUpdateAttempts = 3
+
+// transitionOrder загружает заказ, применяет к нему apply и сохраняет результат.
+// apply меняет статус через методы домена и тем самым проверяет допустимость перехода.
+// Если статус заказа был изменён конкурентно, переход перепроверяется на актуальном состоянии.
+// change попадает в историю статусов вместе с переходом, а подписчики заказа
+// получают уведомление после фиксации транзакции.
+func transitionOrder(
+	ctx context.Context,
+	repo domain.OrderPaymentRepository,
+	notifier statusNotifier,
+	orderUUID string,
+	change domain.StatusChange,
+	apply func(order *domain.Order) error,
+) (domain.Order, error) {
+	const op = "orderUseCase.transitionOrder"
+
+	var err error
+	for attempt := 0; attempt < maxStatusUpdateAttempts; attempt++ {
+		var order domain.Order
+		order, err = repo.FindByUUID(ctx, orderUUID)
+		if err != nil {
+			return domain.Order{}, fmt.Errorf("%s: failed to get order: %w", op, err)
+		}
+
+		from := order.Status
+		if err = apply(&order); err != nil {
+			return domain.Order{}, fmt.Errorf("%s: %w", op, err)
+		}
+
+		var changedAt time.Time
+		changedAt, err = repo.UpdateStatus(ctx, order, from, change)
+		if err == nil {
+			notifier.statusChanged(ctx, order, from, changedAt)
+			return order, nil
+		}
+		if !errors.Is(err, domain.ErrOrderStatusConflict) {
+			return domain.Order{}, fmt.Errorf("%s: failed to update order status: %w", op, err)
+		}
+	}
+
+	return domain.Order{}, fmt.Errorf("%s: %w", op, err)
+}
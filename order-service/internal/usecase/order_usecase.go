@@ -2,11 +2,15 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/google/uuid"
 
+	"github.com/Wrestler094/scalable-ecommerce-platform/pkg/events"
+	"github.com/Wrestler094/scalable-ecommerce-platform/pkg/outbox"
+
 	"github.com/Wrestler094/scalable-ecommerce-platform/order-service/internal/domain"
 )
 
@@ -14,30 +18,96 @@ var _ domain.OrderUseCase = (*OrderUseCase)(nil)
 
 type OrderUseCase struct {
 	orderRepo       domain.OrderRepository
+	idempotencyRepo domain.IdempotencyRepository
 	productProvider domain.ProductProvider
+	cartProvider    domain.CartProvider
 	paymentService  domain.PaymentService
+	outboxWriter    outbox.Writer
+	txManager       domain.TxManager
+	notifier        statusNotifier
+	shippingRates   domain.ShippingRates
 }
 
-func NewOrderUseCase(orderRepo domain.OrderRepository, productProvider domain.ProductProvider, paymentService domain.PaymentService) *OrderUseCase {
+func NewOrderUseCase(
+	orderRepo domain.OrderRepository,
+	idempotencyRepo domain.IdempotencyRepository,
+	productProvider domain.ProductProvider,
+	cartProvider domain.CartProvider,
+	paymentService domain.PaymentService,
+	outboxWriter outbox.Writer,
+	txManager domain.TxManager,
+	events domain.OrderEventPublisher,
+	shippingRates domain.ShippingRates,
+) *OrderUseCase {
 	return &OrderUseCase{
 		orderRepo:       orderRepo,
+		idempotencyRepo: idempotencyRepo,
 		productProvider: productProvider,
+		cartProvider:    cartProvider,
 		paymentService:  paymentService,
+		outboxWriter:    outboxWriter,
+		txManager:       txManager,
+		notifier:        newStatusNotifier(txManager, events),
+		shippingRates:   shippingRates,
 	}
 }
 
-func (s *OrderUseCase) CreateOrder(
+func (s *OrderUseCase) CreateOrder(ctx context.Context, cmd domain.CreateOrderCommand) (domain.Order, string, error) {
+	const op = "orderUseCase.CreateOrder"
+
+	if cmd.IdempotencyKey == "" {
+		return s.createOrder(ctx, cmd.UserID, cmd.Items, cmd.Shipping, nil)
+	}
+
+	key := domain.IdempotencyRecord{
+		UserID:      cmd.UserID,
+		Key:         cmd.IdempotencyKey,
+		Fingerprint: fingerprintOrder(cmd.Items, cmd.Shipping),
+	}
+
+	order, paymentURL, err := s.replayOrder(ctx, key)
+	if !errors.Is(err, domain.ErrIdempotencyKeyNotFound) {
+		return order, paymentURL, err
+	}
+
+	order, paymentURL, err = s.createOrder(ctx, cmd.UserID, cmd.Items, cmd.Shipping, &key)
+	if errors.Is(err, domain.ErrIdempotencyKeyExists) {
+		// Параллельный запрос с тем же ключом сохранил свой заказ раньше, наш откатился
+		return s.replayOrder(ctx, key)
+	}
+	if order.UUID == "" {
+		return domain.Order{}, "", err
+	}
+
+	// Ответ сохраняется и при ошибке платежа: повтор запроса должен вернуть тот же заказ
+	if completeErr := s.idempotencyRepo.Complete(ctx, cmd.UserID, cmd.IdempotencyKey, paymentURL); completeErr != nil {
+		return order, paymentURL, errors.Join(err, fmt.Errorf("%s: %w", op, completeErr))
+	}
+
+	return order, paymentURL, err
+}
+
+// createOrder сохраняет заказ и инициирует оплату.
+// Если передан key, ключ идемпотентности резервируется в той же транзакции, что и заказ.
+func (s *OrderUseCase) createOrder(
 	ctx context.Context,
 	userID int64,
 	items []domain.OrderItemInput,
+	shipping domain.ShippingInput,
+	key *domain.IdempotencyRecord,
 ) (domain.Order, string, error) {
-	const op = "orderUseCase.CreateOrder"
+	const op = "orderUseCase.createOrder"
 
 	if len(items) == 0 {
 		return domain.Order{}, "", fmt.Errorf("%s: no items", op)
 	}
 
-	orderItems, total, err := calculateOrderItems(ctx, items, s.productProvider)
+	shippingCost, err := s.shippingRates.Cost(shipping.Method)
+	if err != nil {
+		return domain.Order{}, "", fmt.Errorf("%s: delivery method %q: %w", op, shipping.Method, err)
+	}
+
+	orderItems, itemsTotal, err := calculateOrderItems(ctx, items, s.productProvider)
 	if err != nil {
 		return domain.Order{}, "", fmt.Errorf("%s: failed to calculate order items: %w", op, err)
 	}
@@ -45,33 +115,236 @@ func (s *OrderUseCase) CreateOrder(
 	order := domain.Order{
 		UUID:        uuid.New().String(),
 		UserID:      userID,
-		Status:      "pending",
+		Status:      domain.OrderStatusPending,
 		Items:       orderItems,
-		TotalAmount: total,
+		TotalAmount: itemsTotal + shippingCost,
 		CreatedAt:   time.Now().UTC(),
+		Shipping: domain.Shipping{
+			Address: shipping.Address,
+			Method:  shipping.Method,
+			Cost:    shippingCost,
+		},
 	}
 
-	if err = s.orderRepo.Create(ctx, order); err != nil {
-		return domain.Order{}, "", fmt.Errorf("%s: failed to save order: %w", op, err)
+	// Заказ и событие order_created сохраняются атомарно, публикацию выполняет outbox.Relay
+	err = s.txManager.WithinTx(ctx, func(txCtx context.Context) error {
+		if err := s.orderRepo.Create(txCtx, order); err != nil {
+			return fmt.Errorf("%s: failed to save order: %w", op, err)
+		}
+
+		if key != nil {
+			key.OrderUUID = order.UUID
+			if err := s.idempotencyRepo.Reserve(txCtx, *key); err != nil {
+				return fmt.Errorf("%s: %w", op, err)
+			}
+		}
+
+		err := writeOutboxEvent(txCtx, s.outboxWriter, events.TopicOrders, events.EventOrderCreated, order.CreatedAt, events.OrderCreatedPayload{
+			OrderUUID: order.UUID,
+			UserID:    order.UserID,
+			Amount:    order.TotalAmount,
+			Items:     toEventItems(order.Items),
+		})
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return domain.Order{}, "", err
 	}
 
-	// TODO: добавить ретрай/очередь на случай падения
+	// Заказ уже сохранён: при недоступности payment-service он остаётся в pending,
+	// и оплату можно инициировать повторно через RetryPayment
 	paymentURL, err := s.paymentService.CreatePayment(ctx, order)
 	if err != nil {
-		return domain.Order{}, "", fmt.Errorf("%s: failed to create payment: %w", op, err)
+		return order, "", fmt.Errorf("%s: failed to create payment: %w", op, err)
+	}
+
+	change := domain.UserStatusChange(userID, "")
+	updated, err := transitionOrder(ctx, s.orderRepo, s.notifier, order.UUID, change, func(order *domain.Order) error {
+		return order.TransitionTo(domain.OrderStatusAwaitingPayment)
+	})
+	if err != nil {
+		return order, "", fmt.Errorf("%s: failed to move order to awaiting payment: %w", op, err)
+	}
+
+	return updated, paymentURL, nil
+}
+
+// Checkout оформляет заказ из корзины пользователя.
+// Оформленные позиции убираются из корзины только после сохранения заказа:
+// если заказ не создан, корзина остаётся нетронутой, и оформление можно повторить.
+func (s *OrderUseCase) Checkout(
+	ctx context.Context,
+	userID int64,
+	shipping domain.ShippingInput,
+) (domain.Order, string, error) {
+	const op = "orderUseCase.Checkout"
+
+	items, err := s.cartProvider.GetCart(ctx, userID)
+	if err != nil {
+		return domain.Order{}, "", fmt.Errorf("%s: failed to get cart: %w", op, err)
+	}
+	if len(items) == 0 {
+		return domain.Order{}, "", fmt.Errorf("%s: %w", op, domain.ErrCartEmpty)
+	}
+
+	order, paymentURL, createErr := s.createOrder(ctx, userID, items, shipping, nil)
+	if order.UUID == "" {
+		return domain.Order{}, "", fmt.Errorf("%s: %w", op, createErr)
+	}
+
+	// Заказ уже сохранён, поэтому ошибка очистки корзины не отменяет оформление
+	if err := s.cartProvider.RemoveCheckedOutItems(ctx, userID, items); err != nil {
+		clearErr := fmt.Errorf("%s: %w: %w", op, domain.ErrCartClearFailed, err)
+		return order, paymentURL, errors.Join(createErr, clearErr)
+	}
+
+	if createErr != nil {
+		return order, paymentURL, fmt.Errorf("%s: %w", op, createErr)
 	}
 
 	return order, paymentURL, nil
 }
 
-func (s *OrderUseCase) ListOrdersByUser(ctx context.Context, userID int64) ([]domain.Order, error) {
-	return s.orderRepo.FindByUserID(ctx, userID)
+func (s *OrderUseCase) ListOrdersByUser(ctx context.Context, filter domain.OrderListFilter) (domain.OrderPage, error) {
+	const op = "orderUseCase.ListOrdersByUser"
+
+	// Без user_id фильтр вернул бы чужие заказы
+	if filter.UserID <= 0 {
+		return domain.OrderPage{}, fmt.Errorf("%s: user id is required: %w", op, domain.ErrInvalidOrderFilter)
+	}
+
+	page, err := listOrders(ctx, s.orderRepo, filter)
+	if err != nil {
+		return domain.OrderPage{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return page, nil
 }
 
 func (s *OrderUseCase) GetOrderByUUID(ctx context.Context, orderID string) (domain.Order, error) {
 	return s.orderRepo.FindByUUID(ctx, orderID)
 }
 
+func (s *OrderUseCase) CancelOrder(ctx context.Context, cmd domain.CancelOrderCommand) (domain.Order, error) {
+	const op = "orderUseCase.CancelOrder"
+
+	var (
+		order   domain.Order
+		wasPaid bool
+	)
+	err := s.txManager.WithinTx(ctx, func(txCtx context.Context) error {
+		change := domain.UserStatusChange(cmd.UserID, cmd.Reason)
+		if cmd.IsAdmin {
+			change = domain.AdminStatusChange(cmd.UserID, cmd.Reason)
+		}
+
+		var err error
+		order, err = transitionOrder(txCtx, s.orderRepo, s.notifier, cmd.OrderUUID, change, func(order *domain.Order) error {
+			if !cmd.IsAdmin {
+				if order.UserID != cmd.UserID {
+					return domain.ErrOrderAccessDenied
+				}
+				// Пользователь может отменить заказ только до оплаты, дальше — только администратор
+				if !order.Status.IsAwaitingPayment() {
+					return domain.ErrOrderCancelNotAllowed
+				}
+			}
+
+			wasPaid = order.Status.IsPaid()
+			return order.Cancel(cmd.Reason, time.Now().UTC())
+		})
+		if err != nil {
+			return fmt.Errorf("%s: failed to cancel order: %w", op, err)
+		}
+
+		cancelledBy := "user"
+		if cmd.IsAdmin {
+			cancelledBy = "admin"
+		}
+
+		err = writeOutboxEvent(txCtx, s.outboxWriter, events.TopicOrders, events.EventOrderCancelled, *order.CancelledAt, events.OrderCancelledPayload{
+			OrderUUID:      order.UUID,
+			UserID:         order.UserID,
+			Amount:         order.TotalAmount,
+			Reason:         order.CancelReason,
+			CancelledBy:    cancelledBy,
+			RefundRequired: wasPaid,
+		})
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return domain.Order{}, err
+	}
+
+	return order, nil
+}
+
+func (s *OrderUseCase) RetryPayment(ctx context.Context, userID int64, orderUUID string) (domain.Order, string, error) {
+	const op = "orderUseCase.RetryPayment"
+
+	order, err := s.orderRepo.FindByUUID(ctx, orderUUID)
+	if err != nil {
+		return domain.Order{}, "", fmt.Errorf("%s: failed to get order: %w", op, err)
+	}
+
+	if order.UserID != userID {
+		return domain.Order{}, "", fmt.Errorf("%s: %w", op, domain.ErrOrderAccessDenied)
+	}
+	if !order.Status.IsAwaitingPayment() {
+		return domain.Order{}, "", fmt.Errorf("%s: order in status %s: %w", op, order.Status, domain.ErrPaymentRetryNotAllowed)
+	}
+
+	paymentURL, err := s.paymentService.CreatePayment(ctx, order)
+	if err != nil {
+		return domain.Order{}, "", fmt.Errorf("%s: failed to create payment: %w", op, err)
+	}
+
+	order, err = transitionOrder(ctx, s.orderRepo, s.notifier, orderUUID, domain.UserStatusChange(userID, ""), func(order *domain.Order) error {
+		if order.Status == domain.OrderStatusAwaitingPayment {
+			return nil
+		}
+		return order.TransitionTo(domain.OrderStatusAwaitingPayment)
+	})
+	if err != nil {
+		return domain.Order{}, "", fmt.Errorf("%s: failed to move order to awaiting payment: %w", op, err)
+	}
+
+	return order, paymentURL, nil
+}
+
+func (s *OrderUseCase) GetOrderHistory(
+	ctx context.Context,
+	userID int64,
+	isAdmin bool,
+	orderUUID string,
+) ([]domain.StatusHistoryEntry, error) {
+	const op = "orderUseCase.GetOrderHistory"
+
+	order, err := s.orderRepo.FindByUUID(ctx, orderUUID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get order: %w", op, err)
+	}
+
+	if !isAdmin && order.UserID != userID {
+		return nil, fmt.Errorf("%s: %w", op, domain.ErrOrderAccessDenied)
+	}
+
+	history, err := s.orderRepo.ListStatusHistory(ctx, order.ID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return history, nil
+}
+
 func calculateOrderItems(
 	ctx context.Context,
 	items []domain.OrderItemInput,
@@ -79,6 +352,7 @@ func calculateOrderItems(
 ) ([]domain.OrderItem, float64, error) {
 	const op = "orderUseCase.calculateOrderItems"
 
+	items = mergeOrderItems(items)
 	productIDs := make([]int64, len(items))
 	for i, item := range items {
 		productIDs[i] = item.ProductID
@@ -113,6 +387,7 @@ func calculateOrderItems(
 			ProductID: item.ProductID,
 			Quantity:  item.Quantity,
 			Price:     product.Price,
+			Product:   domain.NewProductSnapshot(product),
 		}
 
 		total += product.Price * float64(item.Quantity)
@@ -120,3 +395,19 @@ func calculateOrderItems(
 
 	return orderItems, total, nil
 }
+
+// mergeOrderItems объединяет строки одного товара в одну позицию. Позиция заказа
+// определяется товаром: на этом построены возвраты и ключ order_items (order_id, product_id).
+func mergeOrderItems(items []domain.OrderItemInput) []domain.OrderItemInput {
+	merged := make([]domain.OrderItemInput, 0, len(items))
+	index := make(map[int64]int, len(items))
+	for _, item := range items {
+		if i, ok := index[item.ProductID]; ok {
+			merged[i].Quantity += item.Quantity
+			continue
+		}
+		index[item.ProductID] = len(merged)
+		merged = append(merged, item)
+	}
+	return merged
+}
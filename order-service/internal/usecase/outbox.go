@@ -0,0 +1,48 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Wrestler094/scalable-ecommerce-platform/pkg/events"
+	"github.com/Wrestler094/scalable-ecommerce-platform/pkg/outbox"
+
+	"github.com/Wrestler094/scalable-ecommerce-platform/order-service/internal/domain"
+)
+
+// writeOutboxEvent сериализует payload и записывает событие в outbox в рамках транзакции из ctx.
+// timestamp становится временем события: оно совпадает с моментом изменения заказа.
+func writeOutboxEvent(
+	ctx context.Context,
+	w outbox.Writer,
+	topic, eventType string,
+	timestamp time.Time,
+	payload any,
+) error {
+	const op = "orderUseCase.writeOutboxEvent"
+
+	event, err := outbox.NewEvent(topic, eventType, payload)
+	if err != nil {
+		return fmt.Errorf("%s: failed to build %s event: %w", op, eventType, err)
+	}
+	event.CreatedAt = timestamp
+
+	if err := w.Write(ctx, event); err != nil {
+		return fmt.Errorf("%s: failed to write %s event to outbox: %w", op, eventType, err)
+	}
+
+	return nil
+}
+
+func toEventItems(items []domain.OrderItem) []events.OrderItemPayload {
+	result := make([]events.OrderItemPayload, len(items))
+	for i, item := range items {
+		result[i] = events.OrderItemPayload{
+			ProductID: item.ProductID,
+			Quantity:  item.Quantity,
+			Price:     item.Price,
+		}
+	}
+	return result
+}
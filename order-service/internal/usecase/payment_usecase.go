@@ -3,6 +3,12 @@ package usecase
 import (
 	"context"
 	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Wrestler094/scalable-ecommerce-platform/pkg/events"
+	"github.com/Wrestler094/scalable-ecommerce-platform/pkg/outbox"
 
 	"github.com/Wrestler094/scalable-ecommerce-platform/order-service/internal/domain"
 )
@@ -11,21 +17,110 @@ var _ domain.OrderPaymentUseCase = (*PaymentUseCase)(nil)
 
 type PaymentUseCase struct {
 	orderPaymentRepo domain.OrderPaymentRepository
+	inboxRepo        domain.InboxRepository
+	outboxWriter     outbox.Writer
+	txManager        domain.TxManager
+	notifier         statusNotifier
 }
 
-func NewPaymentUseCase(orderPaymentRepo domain.OrderPaymentRepository) *PaymentUseCase {
+func NewPaymentUseCase(
+	orderPaymentRepo domain.OrderPaymentRepository,
+	inboxRepo domain.InboxRepository,
+	outboxWriter outbox.Writer,
+	txManager domain.TxManager,
+	events domain.OrderEventPublisher,
+) *PaymentUseCase {
 	return &PaymentUseCase{
 		orderPaymentRepo: orderPaymentRepo,
+		inboxRepo:        inboxRepo,
+		outboxWriter:     outboxWriter,
+		txManager:        txManager,
+		notifier:         newStatusNotifier(txManager, events),
 	}
 }
 
-func (u *PaymentUseCase) MarkOrderAsPaid(ctx context.Context, orderUUID string) error {
+func (u *PaymentUseCase) MarkOrderAsPaid(ctx context.Context, eventID uuid.UUID, orderUUID string) error {
 	const op = "paymentUseCase.MarkOrderAsPaid"
 
-	err := u.orderPaymentRepo.MarkAsPaid(ctx, orderUUID)
-	if err != nil {
-		return fmt.Errorf("%s: failed to mark order as paid: %w", op, err)
+	return u.txManager.WithinTx(ctx, func(txCtx context.Context) error {
+		// Отметка в inbox откатывается вместе с заказом, поэтому событие применяется ровно один раз
+		if err := u.inboxRepo.MarkProcessed(txCtx, eventID, events.EventPaymentSuccessful); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		order, err := transitionOrder(txCtx, u.orderPaymentRepo, u.notifier, orderUUID, domain.EventStatusChange(eventID, ""), func(order *domain.Order) error {
+			return order.TransitionTo(domain.OrderStatusPaid)
+		})
+		if err != nil {
+			return fmt.Errorf("%s: failed to mark order as paid: %w", op, err)
+		}
+
+		err = writeOutboxEvent(txCtx, u.outboxWriter, events.TopicOrders, events.EventOrderPaid, time.Now().UTC(), events.OrderPaidPayload{
+			OrderUUID: order.UUID,
+			UserID:    order.UserID,
+			Amount:    order.TotalAmount,
+		})
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		return nil
+	})
+}
+
+func (u *PaymentUseCase) MarkOrderPaymentFailed(ctx context.Context, eventID uuid.UUID, orderUUID string, reason string) error {
+	const op = "paymentUseCase.MarkOrderPaymentFailed"
+
+	return u.txManager.WithinTx(ctx, func(txCtx context.Context) error {
+		if err := u.inboxRepo.MarkProcessed(txCtx, eventID, events.EventPaymentFailed); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		_, err := transitionOrder(txCtx, u.orderPaymentRepo, u.notifier, orderUUID, domain.EventStatusChange(eventID, reason), func(order *domain.Order) error {
+			return order.FailPayment(reason)
+		})
+		if err != nil {
+			return fmt.Errorf("%s: failed to mark order payment as failed: %w", op, err)
+		}
+
+		return nil
+	})
+}
+
+func (u *PaymentUseCase) MarkOrderRefunded(ctx context.Context, eventID uuid.UUID, orderUUID string, totalRefunded float64, fullyRefunded bool) error {
+	const op = "paymentUseCase.MarkOrderRefunded"
+
+	to := domain.OrderStatusPartiallyRefunded
+	if fullyRefunded {
+		to = domain.OrderStatusRefunded
 	}
 
-	return nil
+	return u.txManager.WithinTx(ctx, func(txCtx context.Context) error {
+		if err := u.inboxRepo.MarkProcessed(txCtx, eventID, events.EventPaymentRefunded); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		// Сумма сохраняется при любом статусе: частичный возврат оплаченного или отменённого
+		// заказа статус не меняет, но и не теряется
+		if err := u.orderPaymentRepo.UpdateRefundedAmount(txCtx, orderUUID, totalRefunded); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		order, err := u.orderPaymentRepo.FindByUUID(txCtx, orderUUID)
+		if err != nil {
+			return fmt.Errorf("%s: failed to get order: %w", op, err)
+		}
+		if !order.Status.CanTransitionTo(to) {
+			return nil
+		}
+
+		_, err = transitionOrder(txCtx, u.orderPaymentRepo, u.notifier, orderUUID, domain.EventStatusChange(eventID, ""), func(order *domain.Order) error {
+			return order.TransitionTo(to)
+		})
+		if err != nil {
+			return fmt.Errorf("%s: failed to mark order as refunded: %w", op, err)
+		}
+
+		return nil
+	})
 }
@@ -0,0 +1,133 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Wrestler094/scalable-ecommerce-platform/order-service/internal/domain"
+)
+
+var _ domain.ReturnUseCase = (*ReturnUseCase)(nil)
+
+type ReturnUseCase struct {
+	orderRepo  domain.OrderRepository
+	returnRepo domain.ReturnRepository
+}
+
+func NewReturnUseCase(orderRepo domain.OrderRepository, returnRepo domain.ReturnRepository) *ReturnUseCase {
+	return &ReturnUseCase{
+		orderRepo:  orderRepo,
+		returnRepo: returnRepo,
+	}
+}
+
+// RequestReturn создаёт заявку на возврат позиций доставленного заказа.
+// Вернуть можно не больше, чем куплено за вычетом уже возвращённого.
+func (u *ReturnUseCase) RequestReturn(ctx context.Context, cmd domain.RequestReturnCommand) (domain.OrderReturn, error) {
+	const op = "returnUseCase.RequestReturn"
+
+	order, err := u.orderRepo.FindByUUID(ctx, cmd.OrderUUID)
+	if err != nil {
+		return domain.OrderReturn{}, fmt.Errorf("%s: failed to get order: %w", op, err)
+	}
+
+	if order.UserID != cmd.UserID {
+		return domain.OrderReturn{}, fmt.Errorf("%s: %w", op, domain.ErrOrderAccessDenied)
+	}
+	if !order.Status.IsReturnable() {
+		return domain.OrderReturn{}, fmt.Errorf("%s: order in status %s: %w", op, order.Status, domain.ErrReturnNotAllowed)
+	}
+
+	items, refundAmount, err := calculateReturnItems(order, cmd.Items)
+	if err != nil {
+		return domain.OrderReturn{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	ret := domain.OrderReturn{
+		UUID:         uuid.New().String(),
+		OrderUUID:    order.UUID,
+		UserID:       order.UserID,
+		Status:       domain.ReturnStatusRequested,
+		Reason:       strings.TrimSpace(cmd.Reason),
+		Items:        items,
+		RefundAmount: refundAmount,
+		CreatedAt:    time.Now().UTC(),
+	}
+
+	if err := u.returnRepo.Create(ctx, ret); err != nil {
+		return domain.OrderReturn{}, fmt.Errorf("%s: failed to save return: %w", op, err)
+	}
+
+	return ret, nil
+}
+
+func (u *ReturnUseCase) ListOrderReturns(
+	ctx context.Context,
+	userID int64,
+	isAdmin bool,
+	orderUUID string,
+) ([]domain.OrderReturn, error) {
+	const op = "returnUseCase.ListOrderReturns"
+
+	order, err := u.orderRepo.FindByUUID(ctx, orderUUID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get order: %w", op, err)
+	}
+
+	if !isAdmin && order.UserID != userID {
+		return nil, fmt.Errorf("%s: %w", op, domain.ErrOrderAccessDenied)
+	}
+
+	returns, err := u.returnRepo.ListByOrder(ctx, orderUUID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return returns, nil
+}
+
+// calculateReturnItems проверяет запрошенные позиции и считает суммы к возврату по цене покупки.
+func calculateReturnItems(order domain.Order, inputs []domain.ReturnItemInput) ([]domain.ReturnItem, float64, error) {
+	const op = "returnUseCase.calculateReturnItems"
+
+	if len(inputs) == 0 {
+		return nil, 0, fmt.Errorf("%s: no items: %w", op, domain.ErrInvalidReturnItems)
+	}
+
+	orderItems := make(map[int64]domain.OrderItem, len(order.Items))
+	for _, item := range order.Items {
+		orderItems[item.ProductID] = item
+	}
+
+	items := make([]domain.ReturnItem, 0, len(inputs))
+	seen := make(map[int64]struct{}, len(inputs))
+	var total float64
+	for _, in := range inputs {
+		orderItem, ok := orderItems[in.ProductID]
+		if !ok {
+			return nil, 0, fmt.Errorf("%s: product %d is not in order: %w", op, in.ProductID, domain.ErrInvalidReturnItems)
+		}
+		if _, dup := seen[in.ProductID]; dup {
+			return nil, 0, fmt.Errorf("%s: product %d is duplicated: %w", op, in.ProductID, domain.ErrInvalidReturnItems)
+		}
+		if in.Quantity <= 0 || in.Quantity > orderItem.ReturnableQuantity() {
+			return nil, 0, fmt.Errorf("%s: product %d: quantity %d exceeds returnable %d: %w",
+				op, in.ProductID, in.Quantity, orderItem.ReturnableQuantity(), domain.ErrInvalidReturnItems)
+		}
+		seen[in.ProductID] = struct{}{}
+
+		amount := orderItem.Price * float64(in.Quantity)
+		items = append(items, domain.ReturnItem{
+			ProductID: in.ProductID,
+			Quantity:  in.Quantity,
+			Amount:    amount,
+		})
+		total += amount
+	}
+
+	return items, total, nil
+}
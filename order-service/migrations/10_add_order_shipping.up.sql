@@ -0,0 +1,9 @@
+ALTER TABLE orders
+    ADD COLUMN IF NOT EXISTS shipping_recipient TEXT NULL,
+    ADD COLUMN IF NOT EXISTS shipping_phone TEXT NULL,
+    ADD COLUMN IF NOT EXISTS shipping_country TEXT NULL,
+    ADD COLUMN IF NOT EXISTS shipping_city TEXT NULL,
+    ADD COLUMN IF NOT EXISTS shipping_street TEXT NULL,
+    ADD COLUMN IF NOT EXISTS shipping_postal_code TEXT NULL,
+    ADD COLUMN IF NOT EXISTS delivery_method TEXT NULL,
+    ADD COLUMN IF NOT EXISTS shipping_cost NUMERIC(10, 2) NOT NULL DEFAULT 0;
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS order_return_items;
+DROP TABLE IF EXISTS order_returns;
+
+ALTER TABLE order_items
+    DROP COLUMN IF EXISTS refunded_quantity,
+    DROP COLUMN IF EXISTS refunded_amount;
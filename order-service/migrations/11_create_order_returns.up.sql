@@ -0,0 +1,29 @@
+ALTER TABLE order_items
+    ADD COLUMN IF NOT EXISTS refunded_quantity INT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS refunded_amount NUMERIC(10, 2) NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS order_returns (
+    id BIGSERIAL PRIMARY KEY,
+    uuid UUID NOT NULL UNIQUE,
+    order_id BIGINT NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL,
+    status TEXT NOT NULL,
+    reason TEXT NOT NULL,
+    refund_amount NUMERIC(10, 2) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    resolved_by BIGINT NULL,
+    resolution_comment TEXT NULL,
+    resolved_at TIMESTAMPTZ NULL
+);
+
+-- Не больше одной нерассмотренной заявки на заказ
+CREATE UNIQUE INDEX IF NOT EXISTS idx_order_returns_pending ON order_returns (order_id) WHERE status = 'requested';
+CREATE INDEX IF NOT EXISTS idx_order_returns_status_created ON order_returns (status, created_at);
+
+CREATE TABLE IF NOT EXISTS order_return_items (
+    return_id BIGINT NOT NULL REFERENCES order_returns(id) ON DELETE CASCADE,
+    product_id BIGINT NOT NULL,
+    quantity INT NOT NULL,
+    amount NUMERIC(10, 2) NOT NULL,
+    PRIMARY KEY (return_id, product_id)
+);
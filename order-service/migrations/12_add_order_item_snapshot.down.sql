@@ -0,0 +1,6 @@
+ALTER TABLE order_items
+    DROP COLUMN IF EXISTS tax_class,
+    DROP COLUMN IF EXISTS category_name,
+    DROP COLUMN IF EXISTS category_id,
+    DROP COLUMN IF EXISTS sku,
+    DROP COLUMN IF EXISTS product_name;
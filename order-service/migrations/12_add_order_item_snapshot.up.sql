@@ -0,0 +1,8 @@
+-- Снимок товара на момент оформления заказа, чтобы история заказов
+-- не зависела от последующих изменений каталога
+ALTER TABLE order_items
+    ADD COLUMN IF NOT EXISTS product_name TEXT,
+    ADD COLUMN IF NOT EXISTS sku TEXT,
+    ADD COLUMN IF NOT EXISTS category_id BIGINT,
+    ADD COLUMN IF NOT EXISTS category_name TEXT,
+    ADD COLUMN IF NOT EXISTS tax_class TEXT;
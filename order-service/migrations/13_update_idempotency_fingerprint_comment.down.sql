@@ -0,0 +1 @@
+COMMENT ON COLUMN idempotency_keys.fingerprint IS NULL;
@@ -0,0 +1,2 @@
+-- С появлением доставки fingerprint покрывает всё тело запроса, а не только состав заказа
+COMMENT ON COLUMN idempotency_keys.fingerprint IS 'хеш тела исходного запроса: состав заказа и доставка';
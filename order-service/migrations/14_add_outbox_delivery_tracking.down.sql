@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS outbox_pending_created_at_idx;
+
+ALTER TABLE outbox DROP CONSTRAINT IF EXISTS outbox_status_check;
+
+ALTER TABLE outbox
+    DROP COLUMN IF EXISTS last_error,
+    DROP COLUMN IF EXISTS next_attempt_at,
+    DROP COLUMN IF EXISTS attempts,
+    DROP COLUMN IF EXISTS status,
+    DROP COLUMN IF EXISTS topic;
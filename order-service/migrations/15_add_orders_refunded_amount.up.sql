@@ -0,0 +1,3 @@
+-- Сумма всех возвратов средств по заказу. Частичный возврат оплаченного или отменённого
+-- заказа не меняет его статус, поэтому учитывается только здесь
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS refunded_amount NUMERIC(10, 2) NOT NULL DEFAULT 0;
@@ -0,0 +1,3 @@
+ALTER TABLE orders
+    ADD COLUMN IF NOT EXISTS cancel_reason TEXT NULL,
+    ADD COLUMN IF NOT EXISTS cancelled_at TIMESTAMPTZ NULL;
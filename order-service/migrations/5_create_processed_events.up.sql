@@ -0,0 +1,5 @@
+CREATE TABLE IF NOT EXISTS processed_events (
+    event_id UUID PRIMARY KEY,
+    event_type TEXT NOT NULL,
+    processed_at TIMESTAMP DEFAULT now()
+);
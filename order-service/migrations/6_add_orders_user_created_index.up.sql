@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS idx_orders_user_created_at ON orders (user_id, created_at DESC, id DESC);
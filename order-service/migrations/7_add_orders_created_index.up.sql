@@ -0,0 +1,2 @@
+CREATE INDEX IF NOT EXISTS idx_orders_created_at ON orders (created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_orders_status_created_at ON orders (status, created_at DESC, id DESC);
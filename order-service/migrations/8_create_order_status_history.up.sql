@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS order_status_history (
+    id BIGSERIAL PRIMARY KEY,
+    order_id BIGINT NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    from_status TEXT NULL, -- NULL для записи о создании заказа
+    to_status TEXT NOT NULL,
+    actor TEXT NOT NULL, -- user, admin, system или event
+    actor_id BIGINT NULL,
+    reason TEXT NULL,
+    event_id UUID NULL, -- входящее событие, вызвавшее переход
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_status_history_order_id ON order_status_history (order_id, id);
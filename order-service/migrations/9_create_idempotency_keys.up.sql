@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    user_id BIGINT NOT NULL,
+    key TEXT NOT NULL,
+    fingerprint TEXT NOT NULL, -- хеш состава заказа из исходного запроса
+    order_uuid UUID NOT NULL REFERENCES orders(uuid) ON DELETE CASCADE,
+    payment_url TEXT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    completed_at TIMESTAMPTZ NULL, -- NULL, пока исходный запрос не завершён
+    PRIMARY KEY (user_id, key)
+);
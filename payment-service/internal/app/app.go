@@ -11,18 +11,24 @@ import (
 
 	"github.com/Wrestler094/scalable-ecommerce-platform/pkg/adapters"
 	"github.com/Wrestler094/scalable-ecommerce-platform/pkg/events"
+	"github.com/Wrestler094/scalable-ecommerce-platform/pkg/grpcserver"
 	"github.com/Wrestler094/scalable-ecommerce-platform/pkg/healthcheck"
 	"github.com/Wrestler094/scalable-ecommerce-platform/pkg/httpserver"
 	"github.com/Wrestler094/scalable-ecommerce-platform/pkg/logger"
+	"github.com/Wrestler094/scalable-ecommerce-platform/pkg/outbox"
 	"github.com/Wrestler094/scalable-ecommerce-platform/pkg/validator"
 
 	"github.com/Wrestler094/scalable-ecommerce-platform/payment-service/internal/config"
+	grpcHandler "github.com/Wrestler094/scalable-ecommerce-platform/payment-service/internal/delivery/grpc"
 	"github.com/Wrestler094/scalable-ecommerce-platform/payment-service/internal/delivery/http"
 	"github.com/Wrestler094/scalable-ecommerce-platform/payment-service/internal/delivery/http/infra"
 	"github.com/Wrestler094/scalable-ecommerce-platform/payment-service/internal/delivery/http/v1"
-	kafkainfra "github.com/Wrestler094/scalable-ecommerce-platform/payment-service/internal/infrastructure/kafka"
+	kafkadelivery "github.com/Wrestler094/scalable-ecommerce-platform/payment-service/internal/delivery/kafka"
+	"github.com/Wrestler094/scalable-ecommerce-platform/payment-service/internal/delivery/worker"
+	"github.com/Wrestler094/scalable-ecommerce-platform/payment-service/internal/domain"
+	"github.com/Wrestler094/scalable-ecommerce-platform/payment-service/internal/infrastructure/client/order"
 	"github.com/Wrestler094/scalable-ecommerce-platform/payment-service/internal/infrastructure/postgres"
-	"github.com/Wrestler094/scalable-ecommerce-platform/payment-service/internal/infrastructure/redis"
+	"github.com/Wrestler094/scalable-ecommerce-platform/payment-service/internal/infrastructure/provider/fake"
 	"github.com/Wrestler094/scalable-ecommerce-platform/payment-service/internal/infrastructure/txmanager"
 	"github.com/Wrestler094/scalable-ecommerce-platform/payment-service/internal/usecase"
 )
@@ -48,53 +54,118 @@ func Run(cfg *config.Config) {
 
 	runLogger.Info("PostgreSQL connected", "host", cfg.PG.Host, "port", cfg.PG.Port, "db", cfg.PG.DBName)
 
-	// Connect Redis
-	rdb, err := redis.NewClient(cfg.Redis.Addr, cfg.Redis.Password, cfg.Redis.DB)
-	if err != nil {
-		runLogger.Fatal("Redis initialization failed", "error", err)
-	}
-	defer rdb.Close()
-
-	runLogger.Info("Redis connected", "addr", cfg.Redis.Addr)
-
 	// Helpers/Deps
 	rawValidator := validator.NewPlaygroundValidator()
 	httpValidator := adapters.NewHttpValidatorAdapter(rawValidator)
 	txManager := txmanager.NewTxManager(pg.DB, baseLogger)
 	healthManager := healthcheck.NewManager()
 
+	// Services
+	orderProvider, err := order.NewClient(context.Background(), order.Config{
+		URL:     cfg.Clients.Order.URL,
+		Timeout: cfg.Clients.Order.Timeout,
+	})
+	if err != nil {
+		runLogger.WithError(err).Fatal("failed to create order service client")
+	}
+
+	paymentProvider, err := newPaymentProvider(cfg.Provider, baseLogger)
+	if err != nil {
+		runLogger.WithError(err).Fatal("failed to create payment provider")
+	}
+
+	runLogger.Info("Payment provider initialized", "provider", paymentProvider.Name())
+
 	// Repositories
 	paymentRepo := postgres.NewPaymentRepository(pg.DB)
-	outboxRepo := postgres.NewOutboxRepository(pg.DB)
-	idempRepo := redis.NewIdempotencyRepository(rdb.Client)
+	attemptRepo := postgres.NewPaymentAttemptRepository(pg.DB)
+	refundRepo := postgres.NewRefundRepository(pg.DB)
+	intentRepo := postgres.NewPaymentIntentRepository(pg.DB)
+	outboxStore := outbox.NewStore(pg.DB, txmanager.ExtractTx)
+	idempRepo := postgres.NewIdempotencyRepository(pg.DB)
 
 	// Kafka
-	producer := kafkainfra.NewProducer[events.PaymentSuccessfulPayload](cfg.Kafka.Brokers)
-	defer producer.Close()
+	publisher := outbox.NewKafkaPublisher(cfg.Kafka.Brokers)
+	defer publisher.Close()
+
+	runLogger.Info("Kafka publisher initialized")
 
-	runLogger.Info("Kafka producer initialized")
+	relay := outbox.NewRelay(outboxStore, publisher, baseLogger, outbox.RelayConfig{
+		Interval:    cfg.Outbox.PollInterval,
+		BatchSize:   cfg.Outbox.BatchSize,
+		MaxAttempts: cfg.Outbox.MaxAttempts,
+		BaseBackoff: cfg.Outbox.BaseBackoff,
+		MaxBackoff:  cfg.Outbox.MaxBackoff,
+		Lease:       cfg.Outbox.Lease,
+
+		MetricsNamespace: "payment",
+	})
+	relayCtx, relayCancel := context.WithCancel(context.Background())
+	go relay.Run(relayCtx)
 
-	poller := kafkainfra.NewPoller(outboxRepo, producer, baseLogger, events.TopicPayments, 5*time.Second, 100)
-	ctx, pollerCancel := context.WithCancel(context.Background())
-	go poller.Run(ctx)
+	runLogger.Info("Outbox relay initialized")
 
-	runLogger.Info("Kafka poller initialized")
+	cleaner, err := outbox.NewCleaner(outboxStore, baseLogger, outbox.CleanerConfig{
+		Interval:  cfg.Outbox.Retention.Interval,
+		Retention: time.Duration(cfg.Outbox.Retention.Days) * 24 * time.Hour,
+		BatchSize: cfg.Outbox.Retention.BatchSize,
+		Mode:      outbox.RetentionMode(cfg.Outbox.Retention.Mode),
+
+		MetricsNamespace: "payment",
+	})
+	if err != nil {
+		runLogger.WithError(err).Fatal("failed to create outbox cleaner")
+	}
+	go cleaner.Run(relayCtx)
+
+	runLogger.Info("Outbox cleaner initialized", "mode", cfg.Outbox.Retention.Mode, "retention_days", cfg.Outbox.Retention.Days)
 
 	// Use-Cases
-	paymentUseCase := usecase.NewPaymentUseCase(paymentRepo, outboxRepo, idempRepo, txManager)
+	refundUseCase := usecase.NewRefundUseCase(paymentRepo, refundRepo, paymentProvider, outboxStore, txManager)
+	paymentUseCase := usecase.NewPaymentUseCase(paymentRepo, attemptRepo, refundRepo, intentRepo, orderProvider, paymentProvider, outboxStore, idempRepo, refundUseCase, txManager)
+	intentUseCase := usecase.NewPaymentIntentUseCase(intentRepo, cfg.Checkout.BaseURL)
+
+	// Refund Reconcile Worker
+	reconcileWorker := worker.NewRefundReconcileWorker(refundUseCase, baseLogger, cfg.Refunds.StaleAfter, cfg.Refunds.ReconcileInterval, cfg.Refunds.BatchSize)
+	reconcileCtx, reconcileCancel := context.WithCancel(context.Background())
+	go reconcileWorker.Run(reconcileCtx)
+
+	runLogger.Info("Refund reconcile worker initialized", "stale_after", cfg.Refunds.StaleAfter.String())
 
 	// Handlers
 	paymentHandler := v1.NewPaymentHandler(paymentUseCase, httpValidator, baseLogger)
+	refundHandler := v1.NewRefundHandler(refundUseCase, httpValidator, baseLogger)
 	monitoringHandler := infra.NewMonitoringHandler(healthManager)
 
 	// Router
 	router := http.NewRouter(http.Handlers{
 		V1Handlers: v1.Handlers{
 			PaymentHandler: paymentHandler,
+			RefundHandler:  refundHandler,
 		},
 		MonitoringHandler: monitoringHandler,
 	})
 
+	// Kafka Consumer
+	consumer := kafkadelivery.NewConsumer(
+		cfg.Kafka.Brokers,
+		events.TopicOrders,
+		events.PaymentGroup,
+		refundUseCase,
+		paymentUseCase,
+		baseLogger,
+	)
+
+	consumerCtx, consumerCancel := context.WithCancel(context.Background())
+	go consumer.Start(consumerCtx)
+
+	// gRPC Server
+	gRPCServer := grpcserver.New(
+		grpcserver.Port(fmt.Sprintf("%d", cfg.GRPC.Port)),
+	)
+
+	grpcHandler.RegisterServices(gRPCServer.App, intentUseCase, baseLogger)
+
 	// HTTP Server
 	httpServer := httpserver.NewServer(
 		httpserver.Port(fmt.Sprintf(":%d", cfg.HTTP.Port)),
@@ -108,6 +179,10 @@ func Run(cfg *config.Config) {
 		runLogger.WithError(err).Fatal("HTTP server failed to start")
 	}
 
+	// Start gRPC Server
+	runLogger.Info("gRPC server is starting", "port", cfg.GRPC.Port)
+	gRPCServer.Start()
+
 	healthManager.SetReady(true)
 
 	runLogger.Info("Startup complete", logger.LogKeyDurationMS, time.Since(start).String())
@@ -121,6 +196,8 @@ func Run(cfg *config.Config) {
 		runLogger.Info("Received shutdown signal", "signal", sig)
 	case err := <-httpServer.Notify():
 		runLogger.WithError(err).Error("HTTP server reported error")
+	case err := <-gRPCServer.Notify():
+		runLogger.WithError(err).Error("gRPC server reported error")
 	}
 
 	healthManager.SetReady(false)
@@ -132,5 +209,39 @@ func Run(cfg *config.Config) {
 		runLogger.Info("HTTP server gracefully stopped")
 	}
 
-	pollerCancel()
+	if err := gRPCServer.Shutdown(); err != nil {
+		runLogger.WithError(err).Error("gRPC server shutdown failed")
+	} else {
+		runLogger.Info("gRPC server gracefully stopped")
+	}
+
+	relayCancel()
+	reconcileCancel()
+	consumerCancel()
+	if err := consumer.Close(); err != nil {
+		runLogger.WithError(err).Error("Failed to close consumer")
+	} else {
+		runLogger.Info("Kafka consumer closed successfully")
+	}
+}
+
+// newPaymentProvider выбирает платёжного провайдера по конфигурации.
+func newPaymentProvider(cfg config.Provider, log logger.Logger) (domain.PaymentProvider, error) {
+	switch cfg.Name {
+	case fake.Name:
+		p, err := fake.NewProvider(fake.Config{
+			WebhookURL: cfg.Fake.WebhookURL,
+			Secret:     cfg.WebhookSecret,
+			Default: fake.Scenario{
+				Outcome: fake.Outcome(cfg.Fake.Outcome),
+				Delay:   cfg.Fake.Delay,
+			},
+		}, log)
+		if err != nil {
+			return nil, err
+		}
+		return p, nil
+	default:
+		return nil, fmt.Errorf("unknown payment provider %q", cfg.Name)
+	}
 }
@@ -2,21 +2,27 @@ package config
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/caarlos0/env/v11"
 )
 
 type (
 	Config struct {
-		App     App
-		HTTP    HTTP
-		JWT     JWT
-		Log     Log
-		PG      PG
-		Redis   Redis
-		Kafka   Kafka
-		Metrics Metrics
-		Swagger Swagger
+		App      App
+		HTTP     HTTP
+		GRPC     GRPC
+		JWT      JWT
+		Log      Log
+		PG       PG
+		Kafka    Kafka
+		Outbox   Outbox
+		Refunds  Refunds
+		Checkout Checkout
+		Clients  Clients
+		Provider Provider
+		Metrics  Metrics
+		Swagger  Swagger
 	}
 
 	App struct {
@@ -28,6 +34,10 @@ type (
 		Port int `env:"HTTP_PORT,required"`
 	}
 
+	GRPC struct {
+		Port int `env:"GRPC_PORT,required"`
+	}
+
 	JWT struct{}
 
 	Log struct {
@@ -42,16 +52,63 @@ type (
 		DBName   string `env:"DB_NAME,required"`
 	}
 
-	Redis struct {
-		Addr     string `env:"REDIS_ADDR,required"`
-		Password string `env:"REDIS_PASSWORD"`
-		DB       int    `env:"REDIS_DB" env-default:"0"`
-	}
-
 	Kafka struct {
 		Brokers []string `env:"KAFKA_BROKERS,required"`
 	}
 
+	// Outbox — публикация событий из outbox: пакетами, с экспоненциальными повторами
+	Outbox struct {
+		PollInterval time.Duration `env:"OUTBOX_POLL_INTERVAL" envDefault:"5s"`
+		BatchSize    int           `env:"OUTBOX_BATCH_SIZE" envDefault:"100"`
+		MaxAttempts  int           `env:"OUTBOX_MAX_ATTEMPTS" envDefault:"10"`
+		BaseBackoff  time.Duration `env:"OUTBOX_BASE_BACKOFF" envDefault:"1s"`
+		MaxBackoff   time.Duration `env:"OUTBOX_MAX_BACKOFF" envDefault:"5m"`
+		Lease        time.Duration `env:"OUTBOX_LEASE" envDefault:"30s"`
+		Retention    OutboxRetention
+	}
+
+	// OutboxRetention — очистка опубликованных событий: delete удаляет, archive переносит в outbox_archive
+	OutboxRetention struct {
+		Days      int           `env:"OUTBOX_RETENTION_DAYS" envDefault:"7"`
+		Mode      string        `env:"OUTBOX_RETENTION_MODE" envDefault:"delete"`
+		Interval  time.Duration `env:"OUTBOX_CLEANUP_INTERVAL" envDefault:"1h"`
+		BatchSize int           `env:"OUTBOX_CLEANUP_BATCH_SIZE" envDefault:"500"`
+	}
+
+	// Refunds — сверка с провайдером возвратов, зависших в pending
+	Refunds struct {
+		StaleAfter        time.Duration `env:"REFUND_STALE_AFTER" envDefault:"10m"`
+		ReconcileInterval time.Duration `env:"REFUND_RECONCILE_INTERVAL" envDefault:"1m"`
+		BatchSize         int           `env:"REFUND_RECONCILE_BATCH_SIZE" envDefault:"100"`
+	}
+
+	Checkout struct {
+		BaseURL string `env:"CHECKOUT_BASE_URL,required"`
+	}
+
+	Clients struct {
+		Order OrderClient
+	}
+
+	OrderClient struct {
+		URL     string        `env:"ORDER_SERVICE_URL,required"`
+		Timeout time.Duration `env:"ORDER_SERVICE_TIMEOUT" envDefault:"3s"`
+	}
+
+	// Provider — платёжный провайдер и секрет подписи его вебхуков
+	Provider struct {
+		Name          string `env:"PAYMENT_PROVIDER" envDefault:"fake"`
+		WebhookSecret string `env:"PAYMENT_WEBHOOK_SECRET,required"`
+		Fake          FakeProvider
+	}
+
+	// FakeProvider — сценарий встроенного тестового провайдера
+	FakeProvider struct {
+		WebhookURL string        `env:"FAKE_PROVIDER_WEBHOOK_URL" envDefault:"http://localhost:4000/api/v1/payments/webhook"`
+		Outcome    string        `env:"FAKE_PROVIDER_OUTCOME" envDefault:"succeed"`
+		Delay      time.Duration `env:"FAKE_PROVIDER_DELAY" envDefault:"2s"`
+	}
+
 	Metrics struct {
 		Enabled bool `env:"METRICS_ENABLED" envDefault:"false"`
 	}
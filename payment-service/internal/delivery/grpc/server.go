@@ -0,0 +1,21 @@
+package grpc
+
+import (
+	"github.com/Wrestler094/scalable-ecommerce-platform/pkg/logger"
+	"google.golang.org/grpc"
+
+	grpcV1 "github.com/Wrestler094/scalable-ecommerce-platform/payment-service/internal/delivery/grpc/v1"
+	"github.com/Wrestler094/scalable-ecommerce-platform/payment-service/internal/domain"
+	paymentv1 "github.com/Wrestler094/scalable-ecommerce-platform/gen/go/payment/v1"
+)
+
+// RegisterServices регистрирует все gRPC обработчики на сервере.
+func RegisterServices(
+	gRPCServer *grpc.Server,
+	intentUC domain.PaymentIntentUseCase,
+	logger logger.Logger,
+) {
+	// Создаем и регистрируем обработчик для платежей
+	paymentHandler := grpcV1.NewPaymentHandler(intentUC, logger)
+	paymentv1.RegisterPaymentServiceServer(gRPCServer, paymentHandler)
+}
@@ -0,0 +1,59 @@
+package v1
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/Wrestler094/scalable-ecommerce-platform/pkg/logger"
+
+	"github.com/Wrestler094/scalable-ecommerce-platform/payment-service/internal/domain"
+	paymentv1 "github.com/Wrestler094/scalable-ecommerce-platform/gen/go/payment/v1"
+)
+
+type PaymentHandler struct {
+	paymentv1.UnimplementedPaymentServiceServer
+	intentUC domain.PaymentIntentUseCase
+	logger   logger.Logger
+}
+
+func NewPaymentHandler(intentUC domain.PaymentIntentUseCase, logger logger.Logger) *PaymentHandler {
+	return &PaymentHandler{
+		intentUC: intentUC,
+		logger:   logger,
+	}
+}
+
+func (h *PaymentHandler) CreatePaymentIntent(ctx context.Context, req *paymentv1.CreatePaymentIntentRequest) (*paymentv1.CreatePaymentIntentResponse, error) {
+	const op = "grpc.PaymentHandler.CreatePaymentIntent"
+
+	orderUUID, err := uuid.Parse(req.GetOrderUuid())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid order uuid")
+	}
+
+	intent, err := h.intentUC.CreatePaymentIntent(ctx, domain.CreateIntentCommand{
+		OrderUUID: orderUUID,
+		UserID:    req.GetUserId(),
+		Amount:    req.GetAmount(),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidPaymentIntent):
+			return nil, status.Error(codes.InvalidArgument, "invalid payment intent")
+		case errors.Is(err, domain.ErrPaymentIntentMismatch):
+			return nil, status.Error(codes.FailedPrecondition, "payment intent already exists with different parameters")
+		default:
+			h.logger.WithOp(op).WithError(err).Error("failed to create payment intent", "order_uuid", orderUUID)
+			return nil, status.Error(codes.Internal, "internal server error")
+		}
+	}
+
+	return &paymentv1.CreatePaymentIntentResponse{
+		IntentId:    intent.ID.String(),
+		CheckoutUrl: intent.CheckoutURL,
+	}, nil
+}
@@ -1,17 +1,114 @@
 package dto
 
 import (
+	"time"
+
 	"github.com/google/uuid"
+
+	"github.com/Wrestler094/scalable-ecommerce-platform/payment-service/internal/domain"
 )
 
 // ====== Pay ======
 
 type PayRequest struct {
-	OrderUUID      uuid.UUID `json:"order_uuid" validate:"required,uuid4"`
+	OrderUUID uuid.UUID `json:"order_uuid" validate:"required,uuid4"`
+	// IntentID — намерение из checkout URL, необязателен
+	IntentID       uuid.UUID `json:"intent_id"`
 	Amount         float64   `json:"amount" validate:"required,gt=0"`
 	IdempotencyKey string    `json:"idempotency_key" validate:"required"`
 }
 
+// PayResponse повторяется без изменений на запросы с тем же idempotency_key.
 type PayResponse struct {
-	Message string `json:"message"`
+	Message   string    `json:"message"`
+	PaymentID int64     `json:"payment_id"`
+	OrderUUID uuid.UUID `json:"order_uuid"`
+	Status    string    `json:"status"`
+}
+
+// ====== Get / List ======
+
+type PaymentAttempt struct {
+	ID            int64     `json:"id"`
+	Provider      string    `json:"provider"`
+	ProviderRef   string    `json:"provider_ref"`
+	Amount        float64   `json:"amount"`
+	Status        string    `json:"status"`
+	FailureReason string    `json:"failure_reason,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+type Payment struct {
+	ID             int64            `json:"id"`
+	OrderUUID      uuid.UUID        `json:"order_uuid"`
+	UserID         int64            `json:"user_id"`
+	Amount         float64          `json:"amount"`
+	RefundedAmount float64          `json:"refunded_amount"`
+	IntentID       *uuid.UUID       `json:"intent_id,omitempty"`
+	Status         string           `json:"status"`
+	FailureReason  string           `json:"failure_reason,omitempty"`
+	Provider       string           `json:"provider,omitempty"`
+	CreatedAt      time.Time        `json:"created_at"`
+	UpdatedAt      time.Time        `json:"updated_at"`
+	Attempts       []PaymentAttempt `json:"attempts"`
+	Refunds        []Refund         `json:"refunds"`
+}
+
+type GetPaymentResponse struct {
+	Payment Payment `json:"payment"`
+}
+
+type ListPaymentsResponse struct {
+	Payments []Payment `json:"payments"`
+}
+
+func FromPayment(p domain.Payment) Payment {
+	attempts := make([]PaymentAttempt, 0, len(p.Attempts))
+	for _, a := range p.Attempts {
+		attempts = append(attempts, PaymentAttempt{
+			ID:            a.ID,
+			Provider:      a.Provider,
+			ProviderRef:   a.ProviderRef,
+			Amount:        a.Amount,
+			Status:        string(a.Status),
+			FailureReason: a.FailureReason,
+			CreatedAt:     a.CreatedAt,
+			UpdatedAt:     a.UpdatedAt,
+		})
+	}
+
+	refunds := make([]Refund, 0, len(p.Refunds))
+	for _, r := range p.Refunds {
+		refunds = append(refunds, FromRefund(r))
+	}
+
+	var intentID *uuid.UUID
+	if p.IntentID != uuid.Nil {
+		intentID = &p.IntentID
+	}
+
+	return Payment{
+		ID:             p.ID,
+		OrderUUID:      p.OrderUUID,
+		UserID:         p.UserID,
+		Amount:         p.Amount,
+		RefundedAmount: p.RefundedAmount,
+		IntentID:       intentID,
+		Status:         string(p.Status),
+		FailureReason:  p.FailureReason,
+		Provider:       p.Provider,
+		CreatedAt:      p.CreatedAt,
+		UpdatedAt:      p.UpdatedAt,
+		Attempts:       attempts,
+		Refunds:        refunds,
+	}
+}
+
+func FromPayments(payments []domain.Payment) ListPaymentsResponse {
+	resp := ListPaymentsResponse{Payments: make([]Payment, 0, len(payments))}
+	for _, p := range payments {
+		resp.Payments = append(resp.Payments, FromPayment(p))
+	}
+	return resp
 }
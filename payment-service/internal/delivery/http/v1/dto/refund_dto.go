@@ -0,0 +1,50 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/Wrestler094/scalable-ecommerce-platform/payment-service/internal/domain"
+)
+
+// ====== Refunds ======
+
+// CreateRefundRequest — возврат по платежу. Без Amount возвращается весь остаток.
+type CreateRefundRequest struct {
+	Amount         *float64 `json:"amount,omitempty" validate:"omitempty,gt=0"`
+	Reason         string   `json:"reason" validate:"required,max=500"`
+	IdempotencyKey string   `json:"idempotency_key" validate:"required"`
+}
+
+type CreateRefundResponse struct {
+	Refund Refund `json:"refund"`
+}
+
+type Refund struct {
+	ID            int64     `json:"id"`
+	PaymentID     int64     `json:"payment_id"`
+	Amount        float64   `json:"amount"`
+	Status        string    `json:"status"`
+	Source        string    `json:"source"`
+	Reason        string    `json:"reason"`
+	ReturnUUID    string    `json:"return_uuid,omitempty"`
+	RequestedBy   int64     `json:"requested_by,omitempty"`
+	FailureReason string    `json:"failure_reason,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+func FromRefund(r domain.Refund) Refund {
+	return Refund{
+		ID:            r.ID,
+		PaymentID:     r.PaymentID,
+		Amount:        r.Amount,
+		Status:        string(r.Status),
+		Source:        string(r.Source),
+		Reason:        r.Reason,
+		ReturnUUID:    r.ReturnUUID,
+		RequestedBy:   r.RequestedBy,
+		FailureReason: r.FailureReason,
+		CreatedAt:     r.CreatedAt,
+		UpdatedAt:     r.UpdatedAt,
+	}
+}
@@ -2,9 +2,13 @@ package v1
 
 import (
 	"errors"
+	"io"
 	"net/http"
+	"strconv"
 
+	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
 
 	"github.com/Wrestler094/scalable-ecommerce-platform/pkg/authenticator"
 	"github.com/Wrestler094/scalable-ecommerce-platform/pkg/httphelper"
@@ -12,8 +16,12 @@ import (
 
 	"github.com/Wrestler094/scalable-ecommerce-platform/payment-service/internal/delivery/http/v1/dto"
 	"github.com/Wrestler094/scalable-ecommerce-platform/payment-service/internal/domain"
+	"github.com/Wrestler094/scalable-ecommerce-platform/payment-service/internal/infrastructure/provider"
 )
 
+// maxWebhookBodySize — ограничение тела вебхука провайдера
+const maxWebhookBodySize = 1 << 20
+
 type PaymentHandler struct {
 	paymentUC domain.PaymentUseCase
 	validator httphelper.Validator
@@ -52,17 +60,64 @@ func (h *PaymentHandler) Pay(w http.ResponseWriter, r *http.Request) {
 	payCommand := domain.PayCommand{
 		UserID:         userID,
 		OrderUUID:      req.OrderUUID,
+		IntentID:       req.IntentID,
 		Amount:         req.Amount,
 		IdempotencyKey: req.IdempotencyKey,
 	}
 
-	err = h.paymentUC.ProcessPayment(ctx, payCommand)
+	result, err := h.paymentUC.ProcessPayment(ctx, payCommand)
 	if err != nil {
 		log := h.logger.WithOp(op).WithRequestID(middleware.GetReqID(ctx)).WithError(err)
 
 		switch {
-		case errors.Is(err, domain.ErrDuplicatePayment):
-			http.Error(w, "duplicate payment", http.StatusConflict)
+		case errors.Is(err, domain.ErrIdempotencyKeyReused):
+			httphelper.RespondError(w, http.StatusUnprocessableEntity, "idempotency key already used with a different request")
+			return
+
+		case errors.Is(err, domain.ErrIdempotencyRequestInProgress):
+			httphelper.RespondError(w, http.StatusConflict, "request with this idempotency key is in progress")
+			return
+
+		case errors.Is(err, domain.ErrOrderNotFound):
+			httphelper.RespondError(w, http.StatusNotFound, "order not found")
+			return
+
+		case errors.Is(err, domain.ErrOrderAccessDenied):
+			httphelper.RespondError(w, http.StatusForbidden, "forbidden")
+			return
+
+		case errors.Is(err, domain.ErrOrderAlreadyPaid):
+			httphelper.RespondError(w, http.StatusConflict, "order is already paid")
+			return
+
+		case errors.Is(err, domain.ErrOrderNotPayable):
+			httphelper.RespondError(w, http.StatusConflict, "order cannot be paid in its current status")
+			return
+
+		case errors.Is(err, domain.ErrPaymentAmountMismatch):
+			httphelper.RespondError(w, http.StatusUnprocessableEntity, "payment amount does not match order total")
+			return
+
+		case errors.Is(err, domain.ErrPaymentInProgress):
+			httphelper.RespondError(w, http.StatusConflict, "payment for this order is already in progress")
+			return
+
+		case errors.Is(err, domain.ErrPaymentIntentNotFound):
+			httphelper.RespondError(w, http.StatusNotFound, "payment intent not found")
+			return
+
+		case errors.Is(err, domain.ErrPaymentIntentMismatch):
+			httphelper.RespondError(w, http.StatusUnprocessableEntity, "payment intent does not match the order")
+			return
+
+		case errors.Is(err, domain.ErrPaymentProviderFailed):
+			log.Error("payment provider failed", "command", payCommand, "user_id", userID)
+			httphelper.RespondError(w, http.StatusBadGateway, "payment provider failed, try again later")
+			return
+
+		case errors.Is(err, domain.ErrOrderServiceUnavailable):
+			log.Warn("order service unavailable", "command", payCommand, "user_id", userID)
+			httphelper.RespondError(w, http.StatusServiceUnavailable, "order service unavailable, try again later")
 			return
 
 		case errors.Is(err, domain.ErrIdempotencyRegistrationFailed):
@@ -77,6 +132,121 @@ func (h *PaymentHandler) Pay(w http.ResponseWriter, r *http.Request) {
 
 	httphelper.RespondJSON(w, http.StatusAccepted, dto.PayResponse{
 		// TODO: Подумать над сообщением
-		Message: "Payment accepted. Order status will be updated shortly.",
+		Message:   "Payment accepted. Order status will be updated shortly.",
+		PaymentID: result.PaymentID,
+		OrderUUID: result.OrderUUID,
+		Status:    string(result.Status),
 	})
 }
+
+// GetPayment возвращает платёж с историей попыток владельцу или администратору.
+func (h *PaymentHandler) GetPayment(w http.ResponseWriter, r *http.Request) {
+	const op = "paymentHandler.GetPayment"
+
+	ctx := r.Context()
+	requester, ok := paymentRequester(r)
+	if !ok {
+		httphelper.RespondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	paymentID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		httphelper.RespondError(w, http.StatusBadRequest, "invalid payment id")
+		return
+	}
+
+	payment, err := h.paymentUC.GetPayment(ctx, paymentID, requester)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrPaymentNotFound):
+			httphelper.RespondError(w, http.StatusNotFound, "payment not found")
+		case errors.Is(err, domain.ErrPaymentAccessDenied):
+			httphelper.RespondError(w, http.StatusForbidden, "forbidden")
+		default:
+			h.logger.WithOp(op).WithRequestID(middleware.GetReqID(ctx)).WithError(err).Error("Failed to get payment", "payment_id", paymentID)
+			httphelper.RespondError(w, http.StatusInternalServerError, "failed to get payment")
+		}
+		return
+	}
+
+	httphelper.RespondJSON(w, http.StatusOK, dto.GetPaymentResponse{Payment: dto.FromPayment(payment)})
+}
+
+// ListPayments возвращает платежи заказа из параметра order_uuid.
+func (h *PaymentHandler) ListPayments(w http.ResponseWriter, r *http.Request) {
+	const op = "paymentHandler.ListPayments"
+
+	ctx := r.Context()
+	requester, ok := paymentRequester(r)
+	if !ok {
+		httphelper.RespondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	orderUUID, err := uuid.Parse(r.URL.Query().Get("order_uuid"))
+	if err != nil {
+		httphelper.RespondError(w, http.StatusBadRequest, "order_uuid query parameter must be a valid uuid")
+		return
+	}
+
+	payments, err := h.paymentUC.ListPaymentsByOrder(ctx, orderUUID, requester)
+	if err != nil {
+		if errors.Is(err, domain.ErrPaymentAccessDenied) {
+			httphelper.RespondError(w, http.StatusForbidden, "forbidden")
+			return
+		}
+
+		h.logger.WithOp(op).WithRequestID(middleware.GetReqID(ctx)).WithError(err).Error("Failed to list payments", "order_uuid", orderUUID)
+		httphelper.RespondError(w, http.StatusInternalServerError, "failed to list payments")
+		return
+	}
+
+	httphelper.RespondJSON(w, http.StatusOK, dto.FromPayments(payments))
+}
+
+func paymentRequester(r *http.Request) (domain.PaymentRequester, bool) {
+	userID, ok := authenticator.UserID(r.Context())
+	if !ok {
+		return domain.PaymentRequester{}, false
+	}
+
+	role, _ := authenticator.UserRole(r.Context())
+	return domain.PaymentRequester{UserID: userID, IsAdmin: role == authenticator.Admin}, true
+}
+
+// Webhook принимает уведомления платёжного провайдера. Подпись проверяется по сырому телу запроса,
+// поэтому тело читается целиком, без декодирования JSON.
+func (h *PaymentHandler) Webhook(w http.ResponseWriter, r *http.Request) {
+	const op = "paymentHandler.Webhook"
+
+	ctx := r.Context()
+	log := h.logger.WithOp(op).WithRequestID(middleware.GetReqID(ctx))
+
+	payload, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBodySize))
+	if err != nil {
+		httphelper.RespondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	err = h.paymentUC.HandleProviderWebhook(ctx, payload, r.Header.Get(provider.SignatureHeader))
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidWebhookSignature):
+			log.Warn("webhook signature mismatch")
+			httphelper.RespondError(w, http.StatusUnauthorized, "invalid signature")
+		case errors.Is(err, domain.ErrInvalidWebhookPayload):
+			httphelper.RespondError(w, http.StatusBadRequest, "invalid webhook payload")
+		case errors.Is(err, domain.ErrPaymentNotFound):
+			log.WithError(err).Warn("webhook for unknown payment")
+			httphelper.RespondError(w, http.StatusNotFound, "payment not found")
+		default:
+			// 5xx заставит провайдера повторить доставку
+			log.WithError(err).Error("failed to handle webhook")
+			httphelper.RespondError(w, http.StatusInternalServerError, "failed to handle webhook")
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,98 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+
+	"github.com/Wrestler094/scalable-ecommerce-platform/pkg/authenticator"
+	"github.com/Wrestler094/scalable-ecommerce-platform/pkg/httphelper"
+	"github.com/Wrestler094/scalable-ecommerce-platform/pkg/logger"
+
+	"github.com/Wrestler094/scalable-ecommerce-platform/payment-service/internal/delivery/http/v1/dto"
+	"github.com/Wrestler094/scalable-ecommerce-platform/payment-service/internal/domain"
+)
+
+type RefundHandler struct {
+	refundUC  domain.RefundUseCase
+	validator httphelper.Validator
+	logger    logger.Logger
+}
+
+func NewRefundHandler(refundUC domain.RefundUseCase, validator httphelper.Validator, logger logger.Logger) *RefundHandler {
+	return &RefundHandler{
+		refundUC:  refundUC,
+		validator: validator,
+		logger:    logger,
+	}
+}
+
+// CreateRefund возвращает средства по платежу. Без amount возвращается весь остаток.
+func (h *RefundHandler) CreateRefund(w http.ResponseWriter, r *http.Request) {
+	const op = "refundHandler.CreateRefund"
+
+	ctx := r.Context()
+	adminID, ok := authenticator.UserID(ctx)
+	if !ok {
+		httphelper.RespondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	paymentID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		httphelper.RespondError(w, http.StatusBadRequest, "invalid payment id")
+		return
+	}
+
+	req, err := httphelper.DecodeJSON[dto.CreateRefundRequest](r, w)
+	if err != nil {
+		httphelper.RespondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if errFields := h.validator.Validate(req); errFields != nil {
+		httphelper.RespondValidationErrors(w, errFields)
+		return
+	}
+
+	cmd := domain.RefundCommand{
+		PaymentID:      paymentID,
+		Source:         domain.RefundSourceAdmin,
+		Reason:         req.Reason,
+		IdempotencyKey: req.IdempotencyKey,
+		RequestedBy:    adminID,
+	}
+	if req.Amount != nil {
+		cmd.Amount = *req.Amount
+	}
+
+	refund, err := h.refundUC.RefundPayment(ctx, cmd)
+	if err != nil {
+		log := h.logger.WithOp(op).WithRequestID(middleware.GetReqID(ctx)).WithError(err)
+
+		switch {
+		case errors.Is(err, domain.ErrPaymentNotFound):
+			httphelper.RespondError(w, http.StatusNotFound, "payment not found")
+		case errors.Is(err, domain.ErrPaymentNotRefundable):
+			httphelper.RespondError(w, http.StatusConflict, "payment has no captured funds to refund")
+		case errors.Is(err, domain.ErrRefundAmountExceeded):
+			httphelper.RespondError(w, http.StatusUnprocessableEntity, "refund amount exceeds refundable amount")
+		case errors.Is(err, domain.ErrRefundInProgress):
+			httphelper.RespondError(w, http.StatusConflict, "refund with this idempotency key is in progress")
+		case errors.Is(err, domain.ErrRefundIdempotencyMismatch):
+			httphelper.RespondError(w, http.StatusConflict, "idempotency key already used for a different refund")
+		case errors.Is(err, domain.ErrPaymentProviderFailed):
+			log.Error("payment provider failed to refund", "payment_id", paymentID)
+			httphelper.RespondError(w, http.StatusBadGateway, "payment provider failed, try again later")
+		default:
+			log.Error("Failed to refund payment", "payment_id", paymentID)
+			httphelper.RespondError(w, http.StatusInternalServerError, "failed to refund payment")
+		}
+		return
+	}
+
+	httphelper.RespondJSON(w, http.StatusCreated, dto.CreateRefundResponse{Refund: dto.FromRefund(refund)})
+}
@@ -10,6 +10,7 @@ import (
 
 type Handlers struct {
 	PaymentHandler *PaymentHandler
+	RefundHandler  *RefundHandler
 }
 
 func NewV1Router(h Handlers) http.Handler {
@@ -17,9 +18,17 @@ func NewV1Router(h Handlers) http.Handler {
 
 	// Payment routes
 	r.Route("/payments", func(r chi.Router) {
-		r.Use(authenticator.RequireAuth())
+		// Вебхук вызывает провайдер: вместо JWT запрос подписан HMAC
+		r.Post("/webhook", h.PaymentHandler.Webhook)
 
-		r.Post("/pay", h.PaymentHandler.Pay)
+		r.Group(func(r chi.Router) {
+			r.Use(authenticator.RequireAuth())
+
+			r.Post("/pay", h.PaymentHandler.Pay)
+			r.Get("/", h.PaymentHandler.ListPayments)
+			r.Get("/{id}", h.PaymentHandler.GetPayment)
+			r.With(authenticator.RequireAdmin()).Post("/{id}/refunds", h.RefundHandler.CreateRefund)
+		})
 	})
 
 	return r
@@ -0,0 +1,273 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/segmentio/kafka-go"
+
+	"github.com/Wrestler094/scalable-ecommerce-platform/pkg/events"
+	"github.com/Wrestler094/scalable-ecommerce-platform/pkg/logger"
+
+	"github.com/Wrestler094/scalable-ecommerce-platform/payment-service/internal/domain"
+)
+
+// defaultCancelRefundReason — причина возврата, если при отмене заказа она не указана
+const defaultCancelRefundReason = "order cancelled"
+
+// expiredOrderReason — причина отмены оплаты заказа, истёкшего без оплаты
+const expiredOrderReason = "order expired"
+
+const (
+	// retryInitialDelay и retryMaxDelay — пауза между повторами события после временной ошибки
+	retryInitialDelay = time.Second
+	retryMaxDelay     = time.Minute
+)
+
+// Consumer обрабатывает события order-service: запускает возвраты при отмене оплаченного
+// заказа и одобрении заявки на возврат товара, а незавершённую оплату отменённого
+// или истёкшего заказа отменяет у провайдера.
+// Смещение фиксируется только после обработки события: временная ошибка провайдера или базы
+// повторяется, а не теряется. Повтор безопасен — возвраты идемпотентны по ключу события.
+type Consumer struct {
+	reader   *kafka.Reader
+	logger   logger.Logger
+	usecase  domain.RefundUseCase
+	payments domain.PaymentUseCase
+}
+
+func NewConsumer(
+	brokerAddresses []string,
+	topic string,
+	groupID string,
+	uc domain.RefundUseCase,
+	payments domain.PaymentUseCase,
+	logger logger.Logger,
+) *Consumer {
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers: brokerAddresses,
+		GroupID: groupID,
+		Topic:   topic,
+		// Новая группа читает топик с начала, иначе события, опубликованные до её первого
+		// запуска, потеряются. Повторная обработка безопасна: возвраты идемпотентны по ключу события
+		StartOffset: kafka.FirstOffset,
+	})
+
+	return &Consumer{
+		reader:   reader,
+		logger:   logger,
+		usecase:  uc,
+		payments: payments,
+	}
+}
+
+func (c *Consumer) Start(ctx context.Context) {
+	const op = "kafka.Consumer.Start"
+
+	log := c.logger.WithOp(op)
+	log.Info("Kafka consumer started", "topic", c.reader.Config().Topic)
+
+	for {
+		m, err := c.reader.FetchMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				log.Info("Kafka consumer stopped by context")
+				return
+			}
+
+			log.WithError(err).Error("Failed to fetch message")
+			continue
+		}
+
+		// Событие не обработано до остановки: смещение не фиксируется, и после перезапуска оно придёт снова
+		if err := c.process(ctx, log, m); err != nil {
+			log.Info("Kafka consumer stopped by context")
+			return
+		}
+
+		if err := c.reader.CommitMessages(ctx, m); err != nil {
+			if ctx.Err() != nil {
+				log.Info("Kafka consumer stopped by context")
+				return
+			}
+
+			log.WithError(err).Error("Failed to commit message", "offset", m.Offset)
+		}
+	}
+}
+
+// process обрабатывает событие, повторяя его с экспоненциальной задержкой, пока ошибка временная.
+// Возвращает ошибку только при отмене контекста.
+func (c *Consumer) process(ctx context.Context, log logger.Logger, m kafka.Message) error {
+	var envelope events.Envelope[json.RawMessage]
+	if err := json.Unmarshal(m.Value, &envelope); err != nil {
+		log.WithError(err).Error("Failed to unmarshal envelope", "message_key", m.Key)
+		return nil
+	}
+
+	log = log.With("event_id", envelope.EventID, "event_type", envelope.EventType)
+
+	backoff := retryInitialDelay
+	for {
+		err := c.handle(ctx, log, envelope)
+		if err == nil {
+			return nil
+		}
+
+		log.WithError(err).Error("Failed to process event, will retry", "retry_in", backoff)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, retryMaxDelay)
+	}
+}
+
+// handle возвращает ошибку, только если обработку события нужно повторить.
+func (c *Consumer) handle(ctx context.Context, log logger.Logger, envelope events.Envelope[json.RawMessage]) error {
+	switch envelope.EventType {
+	case events.EventOrderCancelled:
+		return c.handleOrderCancelled(ctx, log, envelope.Payload)
+	case events.EventOrderReturnApproved:
+		return c.handleOrderReturnApproved(ctx, log, envelope.Payload)
+	case events.EventOrderExpired:
+		return c.handleOrderExpired(ctx, log, envelope.Payload)
+	default:
+		// В топике заказов много событий, payment-service интересны только отмены и возвраты
+		return nil
+	}
+}
+
+func (c *Consumer) handleOrderCancelled(ctx context.Context, log logger.Logger, raw json.RawMessage) error {
+	var payload events.OrderCancelledPayload
+	if err := json.Unmarshal(raw, &payload); err != nil {
+		log.WithError(err).Error("Failed to unmarshal order cancelled payload")
+		return nil
+	}
+
+	log = log.With("order_id", payload.OrderUUID)
+
+	orderUUID, err := uuid.Parse(payload.OrderUUID)
+	if err != nil {
+		log.WithError(err).Error("Invalid order uuid in order cancelled payload")
+		return nil
+	}
+
+	reason := payload.Reason
+	if reason == "" {
+		reason = defaultCancelRefundReason
+	}
+
+	// Неоплаченный заказ: возвращать нечего, но начатую оплату нужно отменить
+	if !payload.RefundRequired {
+		return c.cancelOrderPayment(ctx, log, orderUUID, reason)
+	}
+
+	// Заказ отменяется один раз, поэтому ключ возврата строится по заказу
+	refund, err := c.usecase.RefundPayment(ctx, domain.RefundCommand{
+		OrderUUID:      orderUUID,
+		Source:         domain.RefundSourceOrderCancelled,
+		Reason:         reason,
+		IdempotencyKey: "order_cancelled:" + payload.OrderUUID,
+	})
+	if err != nil {
+		return skipRejected(log, err)
+	}
+
+	log.Info("Cancelled order refunded", "refund_id", refund.ID, "amount", refund.Amount)
+	return nil
+}
+
+func (c *Consumer) handleOrderReturnApproved(ctx context.Context, log logger.Logger, raw json.RawMessage) error {
+	var payload events.OrderReturnApprovedPayload
+	if err := json.Unmarshal(raw, &payload); err != nil {
+		log.WithError(err).Error("Failed to unmarshal order return approved payload")
+		return nil
+	}
+
+	log = log.With("order_id", payload.OrderUUID, "return_id", payload.ReturnUUID)
+
+	orderUUID, err := uuid.Parse(payload.OrderUUID)
+	if err != nil {
+		log.WithError(err).Error("Invalid order uuid in order return approved payload")
+		return nil
+	}
+
+	refund, err := c.usecase.RefundPayment(ctx, domain.RefundCommand{
+		OrderUUID:      orderUUID,
+		Amount:         payload.Amount,
+		Source:         domain.RefundSourceOrderReturn,
+		Reason:         "order return approved",
+		IdempotencyKey: "order_return:" + payload.ReturnUUID,
+		ReturnUUID:     payload.ReturnUUID,
+	})
+	if err != nil {
+		return skipRejected(log, err)
+	}
+
+	log.Info("Approved return refunded", "refund_id", refund.ID, "amount", refund.Amount)
+	return nil
+}
+
+func (c *Consumer) handleOrderExpired(ctx context.Context, log logger.Logger, raw json.RawMessage) error {
+	var payload events.OrderExpiredPayload
+	if err := json.Unmarshal(raw, &payload); err != nil {
+		log.WithError(err).Error("Failed to unmarshal order expired payload")
+		return nil
+	}
+
+	log = log.With("order_id", payload.OrderUUID)
+
+	orderUUID, err := uuid.Parse(payload.OrderUUID)
+	if err != nil {
+		log.WithError(err).Error("Invalid order uuid in order expired payload")
+		return nil
+	}
+
+	return c.cancelOrderPayment(ctx, log, orderUUID, expiredOrderReason)
+}
+
+func (c *Consumer) cancelOrderPayment(ctx context.Context, log logger.Logger, orderUUID uuid.UUID, reason string) error {
+	if err := c.payments.CancelOrderPayment(ctx, orderUUID, reason); err != nil {
+		return skipRejected(log, err)
+	}
+
+	log.Info("Unfinished order payment closed")
+	return nil
+}
+
+// skipRejected логирует ожидаемый доменный отказ и возвращает nil: повтор события его не изменит.
+// Остальные ошибки — сбой провайдера или базы — возвращаются, чтобы событие было обработано повторно.
+// Повтор после сбоя провайдера заново резервирует сумму неудавшегося возврата с тем же ключом.
+func skipRejected(log logger.Logger, err error) error {
+	switch {
+	case errors.Is(err, domain.ErrRefundInProgress):
+		// Повторная доставка события, пока первый возврат ещё выполняется: его завершит сверка
+		log.Info("Skipping duplicate event")
+	case errors.Is(err, domain.ErrPaymentNotFound):
+		log.WithError(err).Warn("Skipping event: payment not found")
+	case errors.Is(err, domain.ErrPaymentNotRefundable), errors.Is(err, domain.ErrRefundAmountExceeded):
+		log.WithError(err).Warn("Skipping event: payment cannot be refunded")
+	case errors.Is(err, domain.ErrRefundIdempotencyMismatch), errors.Is(err, domain.ErrInvalidPaymentTransition):
+		log.WithError(err).Error("Skipping event: conflicts with payment state")
+	default:
+		return err
+	}
+
+	return nil
+}
+
+func (c *Consumer) Close() error {
+	const op = "kafka.Consumer.Close"
+
+	if err := c.reader.Close(); err != nil {
+		return fmt.Errorf("%s: failed to close Kafka reader: %w", op, err)
+	}
+
+	return nil
+}
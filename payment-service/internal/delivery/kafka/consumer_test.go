@@ -0,0 +1,66 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/Wrestler094/scalable-ecommerce-platform/pkg/events"
+	"github.com/Wrestler094/scalable-ecommerce-platform/pkg/logger"
+
+	"github.com/Wrestler094/scalable-ecommerce-platform/payment-service/internal/domain"
+)
+
+type fakeRefundUseCase struct {
+	domain.RefundUseCase
+	err error
+}
+
+func (uc *fakeRefundUseCase) RefundPayment(_ context.Context, _ domain.RefundCommand) (domain.Refund, error) {
+	return domain.Refund{}, uc.err
+}
+
+func TestConsumerRetriesOnlyTransientErrors(t *testing.T) {
+	log, err := logger.NewLogger("error")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	payload, err := json.Marshal(events.OrderReturnApprovedPayload{
+		OrderUUID:  "5f0c7f38-52d6-4b8e-9a3b-8f0a1c2d3e4f",
+		ReturnUUID: "0b6f1d2c-3e4f-4a5b-8c7d-9e0f1a2b3c4d",
+		Amount:     40,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	envelope := events.Envelope[json.RawMessage]{EventType: events.EventOrderReturnApproved, Payload: payload}
+
+	tests := []struct {
+		name  string
+		err   error
+		retry bool
+	}{
+		{"refunded", nil, false},
+		{"provider failure", fmt.Errorf("refundUseCase.RefundPayment: %w: timeout", domain.ErrPaymentProviderFailed), true},
+		{"database failure", errors.New("connection refused"), true},
+		{"refund in progress", domain.ErrRefundInProgress, false},
+		{"payment not found", domain.ErrPaymentNotFound, false},
+		{"nothing to refund", domain.ErrPaymentNotRefundable, false},
+		{"amount exceeded", domain.ErrRefundAmountExceeded, false},
+		{"key reused", domain.ErrRefundIdempotencyMismatch, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Consumer{logger: log, usecase: &fakeRefundUseCase{err: tt.err}}
+
+			err := c.handle(context.Background(), log, envelope)
+			if (err != nil) != tt.retry {
+				t.Errorf("handle() error = %v, want retry = %v", err, tt.retry)
+			}
+		})
+	}
+}
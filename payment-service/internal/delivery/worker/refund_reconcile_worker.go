@@ -0,0 +1,64 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/Wrestler094/scalable-ecommerce-platform/pkg/logger"
+
+	"github.com/Wrestler094/scalable-ecommerce-platform/payment-service/internal/domain"
+)
+
+// RefundReconcileWorker периодически сверяет с провайдером возвраты, зависшие в pending.
+type RefundReconcileWorker struct {
+	refundUC   domain.RefundUseCase
+	logger     logger.Logger
+	staleAfter time.Duration
+	interval   time.Duration
+	batch      int
+}
+
+func NewRefundReconcileWorker(
+	refundUC domain.RefundUseCase,
+	logger logger.Logger,
+	staleAfter time.Duration,
+	interval time.Duration,
+	batch int,
+) *RefundReconcileWorker {
+	return &RefundReconcileWorker{
+		refundUC:   refundUC,
+		logger:     logger,
+		staleAfter: staleAfter,
+		interval:   interval,
+		batch:      batch,
+	}
+}
+
+func (w *RefundReconcileWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.process(ctx)
+		}
+	}
+}
+
+// process обрабатывает одну пачку за тик: возвраты, которые провайдер ещё проводит,
+// остаются в pending и попали бы в следующую пачку снова.
+func (w *RefundReconcileWorker) process(ctx context.Context) {
+	const op = "worker.RefundReconcileWorker.process"
+
+	settled, err := w.refundUC.ReconcilePendingRefunds(ctx, w.staleAfter, w.batch)
+	if err != nil {
+		w.logger.WithOp(op).WithError(err).Error("failed to reconcile pending refunds")
+	}
+
+	if settled > 0 {
+		w.logger.WithOp(op).Info("settled stale pending refunds", "count", settled)
+	}
+}
@@ -2,9 +2,54 @@ package domain
 
 import (
 	"errors"
+	"fmt"
 )
 
 var (
-	ErrDuplicatePayment              = errors.New("idempotency conflict")
+	ErrIdempotencyKeyNotFound        = errors.New("idempotency key not found")
+	ErrIdempotencyKeyExists          = errors.New("idempotency key already exists")
+	ErrIdempotencyKeyReused          = errors.New("idempotency key reused with different request")
+	ErrIdempotencyRequestInProgress  = errors.New("request with this idempotency key is in progress")
 	ErrIdempotencyRegistrationFailed = errors.New("idempotency registration failed")
+	ErrInvalidPaymentIntent          = errors.New("invalid payment intent")
+	ErrPaymentIntentMismatch         = errors.New("payment intent already exists with different parameters")
+	ErrPaymentIntentNotFound         = errors.New("payment intent not found")
+
+	ErrOrderNotFound           = errors.New("order not found")
+	ErrOrderAccessDenied       = errors.New("order belongs to another user")
+	ErrOrderNotPayable         = errors.New("order cannot be paid in its current status")
+	ErrOrderAlreadyPaid        = errors.New("order is already paid")
+	ErrPaymentAmountMismatch   = errors.New("payment amount does not match order total")
+	ErrOrderServiceUnavailable = errors.New("order service unavailable")
+
+	ErrPaymentNotFound          = errors.New("payment not found")
+	ErrPaymentAccessDenied      = errors.New("payment belongs to another user")
+	ErrInvalidPaymentTransition = errors.New("invalid payment status transition")
+
+	ErrPaymentNotRefundable      = errors.New("payment has no captured funds to refund")
+	ErrRefundAmountExceeded      = errors.New("refund amount exceeds captured amount")
+	ErrRefundInProgress          = errors.New("refund with this idempotency key is in progress")
+	ErrRefundIdempotencyMismatch = errors.New("idempotency key already used for a different refund")
+	ErrRefundNotFound            = errors.New("refund not found")
+	ErrProviderRefundNotFound    = errors.New("refund not found at payment provider")
+	ErrPaymentInProgress         = errors.New("payment for this order is already in progress")
+	ErrPaymentNotCancellable     = errors.New("payment can no longer be cancelled")
+	ErrPaymentProviderFailed     = errors.New("payment provider failed")
+	ErrInvalidWebhookSignature   = errors.New("invalid webhook signature")
+	ErrInvalidWebhookPayload     = errors.New("invalid webhook payload")
 )
+
+// PaymentTransitionError — ошибка недопустимого перехода статуса платежа.
+// Сопоставляется с ErrInvalidPaymentTransition через errors.Is.
+type PaymentTransitionError struct {
+	From PaymentStatus
+	To   PaymentStatus
+}
+
+func (e *PaymentTransitionError) Error() string {
+	return fmt.Sprintf("%s: %s -> %s", ErrInvalidPaymentTransition, e.From, e.To)
+}
+
+func (e *PaymentTransitionError) Is(target error) bool {
+	return target == ErrInvalidPaymentTransition
+}
@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PayResult — ответ на запрос оплаты. Сохраняется вместе с ключом идемпотентности
+// и возвращается повторным запросам с тем же ключом.
+type PayResult struct {
+	PaymentID int64
+	OrderUUID uuid.UUID
+	Status    PaymentStatus
+}
+
+// IdempotencyRecord связывает Idempotency-Key запроса оплаты с его результатом.
+// Ключ уникален в пределах пользователя.
+type IdempotencyRecord struct {
+	UserID int64
+	Key    string
+	// RequestHash — хеш тела запроса, по нему повторный запрос сверяется с исходным
+	RequestHash string
+	OrderUUID   uuid.UUID
+	// Response пустой, пока исходный запрос не завершён
+	Response    *PayResult
+	CreatedAt   time.Time
+	CompletedAt *time.Time
+}
+
+type IdempotencyRepository interface {
+	// Reserve занимает ключ. Незавершённую запись с тем же хешем запроса старше staleAfter
+	// перехватывает: исходный запрос оборвался, не записав платёж.
+	// Возвращает ErrIdempotencyKeyExists, если ключ уже занят.
+	Reserve(ctx context.Context, record IdempotencyRecord, staleAfter time.Duration) error
+	// Find возвращает ErrIdempotencyKeyNotFound, если ключ ещё не использовался.
+	Find(ctx context.Context, userID int64, key string) (IdempotencyRecord, error)
+	// Complete сохраняет ответ исходного запроса.
+	Complete(ctx context.Context, userID int64, key string, result PayResult) error
+	// Release освобождает ключ, если запрос завершился ошибкой, которую можно повторить.
+	Release(ctx context.Context, userID int64, key string) error
+}
@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// OrderStatus — статус заказа в order-service. payment-service различает только
+// оплачиваемые и уже оплаченные заказы, остальные статусы (отменён, в том числе
+// по истечении срока оплаты) оплату запрещают.
+type OrderStatus string
+
+const (
+	OrderStatusPending           OrderStatus = "pending"
+	OrderStatusAwaitingPayment   OrderStatus = "awaiting_payment"
+	OrderStatusPaymentFailed     OrderStatus = "payment_failed"
+	OrderStatusPaid              OrderStatus = "paid"
+	OrderStatusProcessing        OrderStatus = "processing"
+	OrderStatusShipped           OrderStatus = "shipped"
+	OrderStatusDelivered         OrderStatus = "delivered"
+	OrderStatusRefunded          OrderStatus = "refunded"
+	OrderStatusPartiallyRefunded OrderStatus = "partially_refunded"
+)
+
+// IsPayable сообщает, что заказ ожидает оплаты.
+func (s OrderStatus) IsPayable() bool {
+	switch s {
+	case OrderStatusPending, OrderStatusAwaitingPayment, OrderStatusPaymentFailed:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsPaid сообщает, что оплата по заказу уже получена.
+func (s OrderStatus) IsPaid() bool {
+	switch s {
+	case OrderStatusPaid, OrderStatusProcessing, OrderStatusShipped, OrderStatusDelivered,
+		OrderStatusRefunded, OrderStatusPartiallyRefunded:
+		return true
+	default:
+		return false
+	}
+}
+
+// Order — данные заказа из order-service, по которым сверяется платёж.
+type Order struct {
+	UUID        uuid.UUID
+	UserID      int64
+	TotalAmount float64
+	Status      OrderStatus
+}
+
+// amountEpsilon — допуск при сравнении денежных сумм с копейками во float64
+const amountEpsilon = 0.005
+
+// AmountMatches сообщает, совпадает ли сумма платежа с суммой заказа с точностью до копейки.
+func (o Order) AmountMatches(amount float64) bool {
+	return AmountsEqual(o.TotalAmount, amount)
+}
+
+type OrderProvider interface {
+	GetOrder(ctx context.Context, orderUUID uuid.UUID) (Order, error)
+}
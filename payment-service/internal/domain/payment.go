@@ -8,31 +8,94 @@ import (
 )
 
 type Payment struct {
+	ID        int64
 	OrderUUID uuid.UUID
 	UserID    int64
 	Amount    float64
+	// RefundedAmount — сумма успешно выполненных возвратов
+	RefundedAmount float64
 	// TODO: Подумать нужен ли тут CreatedAt
 	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	Status        PaymentStatus
+	FailureReason string
+	// IntentID — намерение оплаты, выданное заказу при оформлении; uuid.Nil для заказов без намерения
+	IntentID uuid.UUID
+	// Provider и ProviderRef относятся к текущей попытке оплаты
+	Provider    string
+	ProviderRef string
+
+	// Attempts и Refunds заполняются только при чтении платежа через API
+	Attempts []PaymentAttempt
+	Refunds  []Refund
+}
+
+// PaymentAttempt — одно обращение к провайдеру по платежу.
+type PaymentAttempt struct {
+	ID            int64
+	PaymentID     int64
+	Provider      string
+	ProviderRef   string
+	Amount        float64
+	Status        PaymentStatus
+	FailureReason string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
 }
 
 type PayCommand struct {
-	UserID         int64
-	OrderUUID      uuid.UUID
+	UserID    int64
+	OrderUUID uuid.UUID
+	// IntentID — намерение из checkout URL; uuid.Nil, если клиент платит по заказу напрямую
+	IntentID       uuid.UUID
 	Amount         float64
 	IdempotencyKey string
 }
 
+// PaymentRequester — пользователь, запрашивающий платежи. Администратор видит любые платежи,
+// пользователь — только свои.
+type PaymentRequester struct {
+	UserID  int64
+	IsAdmin bool
+}
+
+// CanView сообщает, может ли пользователь видеть платёж.
+func (r PaymentRequester) CanView(p Payment) bool {
+	return r.IsAdmin || p.UserID == r.UserID
+}
+
 type PaymentUseCase interface {
-	ProcessPayment(ctx context.Context, cmd PayCommand) error
+	// ProcessPayment запускает оплату. Повтор с тем же IdempotencyKey возвращает сохранённый результат.
+	ProcessPayment(ctx context.Context, cmd PayCommand) (PayResult, error)
+	// HandleProviderWebhook проверяет подпись уведомления провайдера и применяет его к платежу.
+	HandleProviderWebhook(ctx context.Context, payload []byte, signature string) error
+	// GetPayment и ListPaymentsByOrder возвращают платежи вместе с историей попыток и возвратов.
+	GetPayment(ctx context.Context, id int64, requester PaymentRequester) (Payment, error)
+	ListPaymentsByOrder(ctx context.Context, orderUUID uuid.UUID, requester PaymentRequester) ([]Payment, error)
+	// CancelOrderPayment отменяет незавершённую оплату заказа, который больше нельзя оплатить,
+	// а уже проведённое списание по нему возвращает.
+	CancelOrderPayment(ctx context.Context, orderUUID uuid.UUID, reason string) error
 }
 
 type PaymentRepository interface {
-	Create(ctx context.Context, payment Payment) error
+	Create(ctx context.Context, payment Payment) (int64, error)
+	// FindByOrderUUID и FindByProviderRef внутри транзакции блокируют строку платежа до её завершения.
+	// FindByProviderRef ищет платёж по ссылке любой из его попыток.
+	FindByID(ctx context.Context, id int64) (Payment, error)
+	FindByOrderUUID(ctx context.Context, orderUUID uuid.UUID) (Payment, error)
+	FindByProviderRef(ctx context.Context, providerRef string) (Payment, error)
+	ListByOrderUUID(ctx context.Context, orderUUID uuid.UUID) ([]Payment, error)
+	Update(ctx context.Context, payment Payment) error
 }
 
-type IdempotencyRepository interface {
-	Exists(ctx context.Context, key string) (bool, error)
-	Register(ctx context.Context, key string) error
+type PaymentAttemptRepository interface {
+	Create(ctx context.Context, attempt PaymentAttempt) error
+	// FindByProviderRef внутри транзакции блокирует строку попытки до её завершения.
+	FindByProviderRef(ctx context.Context, providerRef string) (PaymentAttempt, error)
+	// ListByPaymentIDs возвращает попытки платежей в порядке их создания.
+	ListByPaymentIDs(ctx context.Context, paymentIDs []int64) ([]PaymentAttempt, error)
+	Update(ctx context.Context, attempt PaymentAttempt) error
 }
 
 type TxManager interface {
@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PaymentIntent — намерение оплатить заказ, зарегистрированное до самого платежа.
+type PaymentIntent struct {
+	ID          uuid.UUID
+	OrderUUID   uuid.UUID
+	UserID      int64
+	Amount      float64
+	CheckoutURL string
+	CreatedAt   time.Time
+}
+
+type CreateIntentCommand struct {
+	OrderUUID uuid.UUID
+	UserID    int64
+	Amount    float64
+}
+
+type PaymentIntentUseCase interface {
+	CreatePaymentIntent(ctx context.Context, cmd CreateIntentCommand) (PaymentIntent, error)
+}
+
+type PaymentIntentRepository interface {
+	// CreateOrGet сохраняет намерение оплаты. Если для заказа оно уже есть — возвращает существующее.
+	CreateOrGet(ctx context.Context, intent PaymentIntent) (PaymentIntent, error)
+	// FindByID и FindByOrderUUID возвращают ErrPaymentIntentNotFound, если намерения нет.
+	FindByID(ctx context.Context, id uuid.UUID) (PaymentIntent, error)
+	FindByOrderUUID(ctx context.Context, orderUUID uuid.UUID) (PaymentIntent, error)
+}
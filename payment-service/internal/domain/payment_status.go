@@ -0,0 +1,84 @@
+package domain
+
+// PaymentStatus — состояние платежа. Итог платежа приходит от провайдера асинхронно, вебхуком.
+type PaymentStatus string
+
+const (
+	// PaymentStatusCreated — платёж зарегистрирован у провайдера, списание ещё не запрошено
+	PaymentStatusCreated PaymentStatus = "created"
+	// PaymentStatusPending — списание запрошено, ждём итог от провайдера
+	PaymentStatusPending PaymentStatus = "pending"
+	// PaymentStatusAuthorized — средства заблокированы, но ещё не списаны
+	PaymentStatusAuthorized PaymentStatus = "authorized"
+	PaymentStatusCaptured   PaymentStatus = "captured"
+	PaymentStatusFailed     PaymentStatus = "failed"
+	PaymentStatusRefunded   PaymentStatus = "refunded"
+	// PaymentStatusPartiallyRefunded — вернули часть списанной суммы
+	PaymentStatusPartiallyRefunded PaymentStatus = "partially_refunded"
+)
+
+// paymentTransitions описывает допустимые переходы между статусами платежа.
+// Статусы, отсутствующие в качестве ключа, считаются терминальными.
+var paymentTransitions = map[PaymentStatus][]PaymentStatus{
+	// Вебхук может опередить ответ провайдера на запрос списания, поэтому из created
+	// платёж переходит сразу в любое состояние, о котором сообщает провайдер
+	PaymentStatusCreated:    {PaymentStatusPending, PaymentStatusAuthorized, PaymentStatusCaptured, PaymentStatusFailed},
+	PaymentStatusPending:    {PaymentStatusAuthorized, PaymentStatusCaptured, PaymentStatusFailed},
+	PaymentStatusAuthorized: {PaymentStatusCaptured, PaymentStatusFailed},
+	PaymentStatusCaptured:   {PaymentStatusPartiallyRefunded, PaymentStatusRefunded},
+	// Повторные частичные возвраты оставляют платёж в partially_refunded, пока не возвращена вся сумма
+	PaymentStatusPartiallyRefunded: {PaymentStatusPartiallyRefunded, PaymentStatusRefunded},
+	// После неудачи заказ можно оплатить повторно: платёж начинает новую попытку
+	PaymentStatusFailed: {PaymentStatusCreated},
+}
+
+// IsInProgress сообщает, что итог текущей попытки оплаты ещё не известен.
+func (s PaymentStatus) IsInProgress() bool {
+	switch s {
+	case PaymentStatusCreated, PaymentStatusPending, PaymentStatusAuthorized:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsCaptured сообщает, что средства по платежу были списаны, в том числе если их потом вернули.
+func (s PaymentStatus) IsCaptured() bool {
+	switch s {
+	case PaymentStatusCaptured, PaymentStatusPartiallyRefunded, PaymentStatusRefunded:
+		return true
+	default:
+		return false
+	}
+}
+
+// CanTransitionTo сообщает, разрешён ли переход из текущего статуса в статус to.
+func (s PaymentStatus) CanTransitionTo(to PaymentStatus) bool {
+	for _, allowed := range paymentTransitions[s] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// TransitionTo переводит платёж в статус to, если переход разрешён.
+// В противном случае возвращает *PaymentTransitionError, статус платежа не меняется.
+func (p *Payment) TransitionTo(to PaymentStatus) error {
+	if !p.Status.CanTransitionTo(to) {
+		return &PaymentTransitionError{From: p.Status, To: to}
+	}
+
+	p.Status = to
+	return nil
+}
+
+// TransitionTo переводит попытку в статус to по тем же правилам, что и платёж.
+func (a *PaymentAttempt) TransitionTo(to PaymentStatus) error {
+	if !a.Status.CanTransitionTo(to) {
+		return &PaymentTransitionError{From: a.Status, To: to}
+	}
+
+	a.Status = to
+	return nil
+}
@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPaymentStatusCanTransitionTo(t *testing.T) {
+	tests := []struct {
+		name string
+		from PaymentStatus
+		to   PaymentStatus
+		want bool
+	}{
+		{"created to pending", PaymentStatusCreated, PaymentStatusPending, true},
+		{"webhook ahead of capture response", PaymentStatusCreated, PaymentStatusCaptured, true},
+		{"created to failed", PaymentStatusCreated, PaymentStatusFailed, true},
+		{"pending to authorized", PaymentStatusPending, PaymentStatusAuthorized, true},
+		{"pending to captured", PaymentStatusPending, PaymentStatusCaptured, true},
+		{"authorized to captured", PaymentStatusAuthorized, PaymentStatusCaptured, true},
+		{"authorized to failed", PaymentStatusAuthorized, PaymentStatusFailed, true},
+		{"captured to partially refunded", PaymentStatusCaptured, PaymentStatusPartiallyRefunded, true},
+		{"captured to refunded", PaymentStatusCaptured, PaymentStatusRefunded, true},
+		{"repeated partial refund", PaymentStatusPartiallyRefunded, PaymentStatusPartiallyRefunded, true},
+		{"partially refunded to refunded", PaymentStatusPartiallyRefunded, PaymentStatusRefunded, true},
+		{"failed payment retried", PaymentStatusFailed, PaymentStatusCreated, true},
+
+		{"pending back to created", PaymentStatusPending, PaymentStatusCreated, false},
+		{"authorized back to pending", PaymentStatusAuthorized, PaymentStatusPending, false},
+		{"captured to failed", PaymentStatusCaptured, PaymentStatusFailed, false},
+		{"duplicate capture webhook", PaymentStatusCaptured, PaymentStatusCaptured, false},
+		{"failed to captured", PaymentStatusFailed, PaymentStatusCaptured, false},
+		{"pending to refunded", PaymentStatusPending, PaymentStatusRefunded, false},
+		{"refunded is terminal", PaymentStatusRefunded, PaymentStatusPartiallyRefunded, false},
+		{"unknown status", PaymentStatus("lost"), PaymentStatusCaptured, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.from.CanTransitionTo(tt.to); got != tt.want {
+				t.Errorf("%s.CanTransitionTo(%s) = %v, want %v", tt.from, tt.to, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPaymentStatusPredicates(t *testing.T) {
+	tests := []struct {
+		status       PaymentStatus
+		wantProgress bool
+		wantCaptured bool
+	}{
+		{PaymentStatusCreated, true, false},
+		{PaymentStatusPending, true, false},
+		{PaymentStatusAuthorized, true, false},
+		{PaymentStatusCaptured, false, true},
+		{PaymentStatusPartiallyRefunded, false, true},
+		{PaymentStatusRefunded, false, true},
+		{PaymentStatusFailed, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.status), func(t *testing.T) {
+			if got := tt.status.IsInProgress(); got != tt.wantProgress {
+				t.Errorf("IsInProgress() = %v, want %v", got, tt.wantProgress)
+			}
+			if got := tt.status.IsCaptured(); got != tt.wantCaptured {
+				t.Errorf("IsCaptured() = %v, want %v", got, tt.wantCaptured)
+			}
+		})
+	}
+}
+
+func TestPaymentTransitionTo(t *testing.T) {
+	payment := Payment{Status: PaymentStatusCaptured}
+
+	err := payment.TransitionTo(PaymentStatusFailed)
+	if !errors.Is(err, ErrInvalidPaymentTransition) {
+		t.Fatalf("TransitionTo(failed) error = %v, want %v", err, ErrInvalidPaymentTransition)
+	}
+	if payment.Status != PaymentStatusCaptured {
+		t.Errorf("status after rejected transition = %s, want %s", payment.Status, PaymentStatusCaptured)
+	}
+
+	if err := payment.TransitionTo(PaymentStatusRefunded); err != nil {
+		t.Fatalf("TransitionTo(refunded) error = %v", err)
+	}
+	if payment.Status != PaymentStatusRefunded {
+		t.Errorf("status = %s, want %s", payment.Status, PaymentStatusRefunded)
+	}
+}
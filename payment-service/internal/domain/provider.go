@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ProviderIntentRequest — параметры платежа, который регистрируется у провайдера.
+type ProviderIntentRequest struct {
+	OrderUUID      uuid.UUID
+	Amount         float64
+	IdempotencyKey string
+}
+
+// ProviderIntent — платёж, зарегистрированный у провайдера. Ref — его идентификатор у провайдера.
+type ProviderIntent struct {
+	Ref string
+}
+
+// ProviderRefund — возврат у провайдера. Status — его итог в терминах возвратов сервиса.
+type ProviderRefund struct {
+	Ref    string
+	Status RefundStatus
+}
+
+// WebhookEventType — тип уведомления провайдера, общий для всех провайдеров.
+type WebhookEventType string
+
+const (
+	WebhookPaymentAuthorized WebhookEventType = "payment.authorized"
+	WebhookPaymentCaptured   WebhookEventType = "payment.captured"
+	WebhookPaymentFailed     WebhookEventType = "payment.failed"
+)
+
+// WebhookEvent — уведомление провайдера об итоге операции, приведённое к общему виду.
+type WebhookEvent struct {
+	ID            string
+	Type          WebhookEventType
+	ProviderRef   string
+	Amount        float64
+	FailureReason string
+	OccurredAt    time.Time
+}
+
+// PaymentProvider — платёжный шлюз (эквайер). Use-case слой работает только с этим интерфейсом,
+// поэтому подключение реального провайдера не требует изменений в бизнес-логике.
+type PaymentProvider interface {
+	// Name — идентификатор провайдера, сохраняется вместе с платежом.
+	Name() string
+	// CreateIntent регистрирует платёж у провайдера.
+	CreateIntent(ctx context.Context, req ProviderIntentRequest) (ProviderIntent, error)
+	// Capture запускает списание. Итог операции приходит вебхуком.
+	Capture(ctx context.Context, providerRef string) error
+	// Cancel отменяет платёж, списание по которому ещё не проведено. Если списание уже
+	// запущено, возвращает ErrPaymentNotCancellable — итог тогда придёт вебхуком.
+	Cancel(ctx context.Context, providerRef string) error
+	// Refund возвращает amount по списанному платежу и возвращает идентификатор возврата у провайдера.
+	Refund(ctx context.Context, providerRef string, amount float64, idempotencyKey string) (string, error)
+	// GetRefund ищет возврат по ключу идемпотентности, с которым он был запрошен.
+	// Если провайдер такого запроса не получал, возвращает ErrProviderRefundNotFound.
+	GetRefund(ctx context.Context, idempotencyKey string) (ProviderRefund, error)
+	// ParseWebhook проверяет подпись уведомления и разбирает его.
+	// При неверной подписи возвращает ErrInvalidWebhookSignature.
+	ParseWebhook(payload []byte, signature string) (WebhookEvent, error)
+}
@@ -0,0 +1,107 @@
+package domain
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RefundStatus — состояние возврата. Сумма pending-возврата уже зарезервирована
+// и не может быть возвращена повторно, пока провайдер не ответит.
+type RefundStatus string
+
+const (
+	RefundStatusPending   RefundStatus = "pending"
+	RefundStatusSucceeded RefundStatus = "succeeded"
+	RefundStatusFailed    RefundStatus = "failed"
+)
+
+// RefundSource — откуда пришёл запрос на возврат.
+type RefundSource string
+
+const (
+	RefundSourceAdmin          RefundSource = "admin"
+	RefundSourceOrderCancelled RefundSource = "order_cancelled"
+	RefundSourceOrderReturn    RefundSource = "order_return"
+	// RefundSourceLateCapture — списание пришло после отмены или истечения заказа
+	RefundSourceLateCapture RefundSource = "late_capture"
+)
+
+type Refund struct {
+	ID             int64
+	PaymentID      int64
+	Amount         float64
+	Status         RefundStatus
+	Source         RefundSource
+	Reason         string
+	IdempotencyKey string
+	// ReturnUUID заполняется для возвратов по одобренной заявке на возврат товара
+	ReturnUUID    string
+	RequestedBy   int64
+	ProviderRef   string
+	FailureReason string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// RefundCommand — запрос на возврат средств по платежу. Платёж ищется по PaymentID,
+// а если он не задан — по OrderUUID. Нулевой Amount означает возврат всего остатка.
+// Повторный запрос с тем же IdempotencyKey возвращает уже выполненный возврат.
+type RefundCommand struct {
+	PaymentID      int64
+	OrderUUID      uuid.UUID
+	Amount         float64
+	Source         RefundSource
+	Reason         string
+	IdempotencyKey string
+	ReturnUUID     string
+	RequestedBy    int64
+}
+
+// SameRequest сообщает, что возврат создан тем же запросом, что и cmd.
+func (r Refund) SameRequest(cmd RefundCommand) bool {
+	return r.Source == cmd.Source && (cmd.Amount == 0 || AmountsEqual(r.Amount, cmd.Amount))
+}
+
+// RefundableAmount — сколько ещё можно вернуть по платежу с учётом уже зарезервированных возвратов.
+func (p Payment) RefundableAmount(pending float64) float64 {
+	return RoundAmount(p.Amount - p.RefundedAmount - pending)
+}
+
+// IsFullyRefunded сообщает, что по платежу возвращена вся списанная сумма.
+func (p Payment) IsFullyRefunded() bool {
+	return p.RefundedAmount > p.Amount-amountEpsilon
+}
+
+// RoundAmount округляет сумму до копеек.
+func RoundAmount(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
+
+// AmountsEqual сравнивает денежные суммы с точностью до копейки.
+func AmountsEqual(a, b float64) bool {
+	return math.Abs(a-b) < amountEpsilon
+}
+
+type RefundUseCase interface {
+	// RefundPayment резервирует сумму, возвращает её через провайдера и публикует payment_refunded.
+	RefundPayment(ctx context.Context, cmd RefundCommand) (Refund, error)
+	// ReconcilePendingRefunds сверяет с провайдером возвраты, которые дольше staleAfter остаются в pending,
+	// и возвращает число завершённых.
+	ReconcilePendingRefunds(ctx context.Context, staleAfter time.Duration, limit int) (int, error)
+}
+
+type RefundRepository interface {
+	Create(ctx context.Context, refund Refund) (int64, error)
+	// FindByIdempotencyKey внутри транзакции блокирует строку возврата до её завершения.
+	FindByIdempotencyKey(ctx context.Context, key string) (Refund, error)
+	FindByID(ctx context.Context, id int64) (Refund, error)
+	// PendingAmount — сумма возвратов платежа, ожидающих ответа провайдера.
+	PendingAmount(ctx context.Context, paymentID int64) (float64, error)
+	ListByPaymentIDs(ctx context.Context, paymentIDs []int64) ([]Refund, error)
+	// ListStalePending возвращает pending-возвраты, не менявшиеся дольше staleAfter, начиная с самых старых.
+	ListStalePending(ctx context.Context, staleAfter time.Duration, limit int) ([]Refund, error)
+	Update(ctx context.Context, refund Refund) error
+}
@@ -0,0 +1,76 @@
+package domain
+
+import "testing"
+
+func TestPaymentRefundableAmount(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   float64
+		refunded float64
+		pending  float64
+		want     float64
+	}{
+		{"nothing refunded", 100, 0, 0, 100},
+		{"partially refunded", 100, 30, 0, 70},
+		{"pending refund reserves its amount", 100, 30, 20, 50},
+		{"fully refunded", 100, 100, 0, 0},
+		{"fully reserved", 100, 40, 60, 0},
+		{"float cents are rounded", 0.3, 0.1, 0.1, 0.1},
+		{"many small refunds", 10, 0.1 + 0.2 + 0.3, 0.7, 8.7},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := Payment{Amount: tt.amount, RefundedAmount: tt.refunded}
+			if got := p.RefundableAmount(tt.pending); got != tt.want {
+				t.Errorf("RefundableAmount(%v) = %v, want %v", tt.pending, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPaymentIsFullyRefunded(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   float64
+		refunded float64
+		want     bool
+	}{
+		{"nothing refunded", 100, 0, false},
+		{"one cent left", 100, 99.99, false},
+		{"exact amount", 100, 100, true},
+		{"float sum of parts", 0.3, 0.1 + 0.2, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := Payment{Amount: tt.amount, RefundedAmount: tt.refunded}
+			if got := p.IsFullyRefunded(); got != tt.want {
+				t.Errorf("IsFullyRefunded() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRefundSameRequest(t *testing.T) {
+	refund := Refund{Amount: 25.5, Source: RefundSourceAdmin}
+
+	tests := []struct {
+		name string
+		cmd  RefundCommand
+		want bool
+	}{
+		{"same amount and source", RefundCommand{Amount: 25.5, Source: RefundSourceAdmin}, true},
+		{"remaining amount requested", RefundCommand{Source: RefundSourceAdmin}, true},
+		{"different amount", RefundCommand{Amount: 25, Source: RefundSourceAdmin}, false},
+		{"different source", RefundCommand{Amount: 25.5, Source: RefundSourceOrderCancelled}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := refund.SameRequest(tt.cmd); got != tt.want {
+				t.Errorf("SameRequest(%+v) = %v, want %v", tt.cmd, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package order
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	orderv1 "github.com/Wrestler094/scalable-ecommerce-platform/gen/go/order/v1"
+	"github.com/Wrestler094/scalable-ecommerce-platform/payment-service/internal/domain"
+)
+
+type Config struct {
+	URL     string
+	Timeout time.Duration
+}
+
+type Client struct {
+	client  orderv1.OrderServiceClient
+	timeout time.Duration
+}
+
+var _ domain.OrderProvider = (*Client)(nil)
+
+func NewClient(ctx context.Context, cfg Config) (*Client, error) {
+	conn, err := grpc.NewClient(cfg.URL, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to order service: %w", err)
+	}
+
+	go func() {
+		<-ctx.Done()
+		conn.Close()
+	}()
+
+	return &Client{
+		client:  orderv1.NewOrderServiceClient(conn),
+		timeout: cfg.Timeout,
+	}, nil
+}
+
+// GetOrder возвращает сумму, владельца и статус заказа для сверки платежа.
+func (c *Client) GetOrder(ctx context.Context, orderUUID uuid.UUID) (domain.Order, error) {
+	const op = "order.Client.GetOrder"
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	resp, err := c.client.GetOrderTotal(ctx, &orderv1.GetOrderTotalRequest{OrderUuid: orderUUID.String()})
+	if err != nil {
+		switch status.Code(err) {
+		case codes.NotFound:
+			return domain.Order{}, fmt.Errorf("%s: %w", op, domain.ErrOrderNotFound)
+		case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted:
+			return domain.Order{}, fmt.Errorf("%s: %w: %w", op, domain.ErrOrderServiceUnavailable, err)
+		default:
+			return domain.Order{}, fmt.Errorf("%s: failed to get order total: %w", op, err)
+		}
+	}
+
+	id, err := uuid.Parse(resp.GetOrderUuid())
+	if err != nil {
+		return domain.Order{}, fmt.Errorf("%s: invalid order uuid in response: %w", op, err)
+	}
+
+	return domain.Order{
+		UUID:        id,
+		UserID:      resp.GetUserId(),
+		TotalAmount: resp.GetTotalAmount(),
+		Status:      domain.OrderStatus(resp.GetStatus()),
+	}, nil
+}
@@ -0,0 +1,61 @@
+package dao
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Wrestler094/scalable-ecommerce-platform/payment-service/internal/domain"
+)
+
+type IdempotencyRecord struct {
+	UserID      int64      `db:"user_id"`
+	Key         string     `db:"key"`
+	RequestHash string     `db:"request_hash"`
+	OrderUUID   uuid.UUID  `db:"order_uuid"`
+	Response    []byte     `db:"response"`
+	CreatedAt   time.Time  `db:"created_at"`
+	CompletedAt *time.Time `db:"completed_at"`
+}
+
+// PayResponse — сохранённый в JSONB ответ на запрос оплаты
+type PayResponse struct {
+	PaymentID int64     `json:"payment_id"`
+	OrderUUID uuid.UUID `json:"order_uuid"`
+	Status    string    `json:"status"`
+}
+
+func FromDomainPayResult(r domain.PayResult) ([]byte, error) {
+	return json.Marshal(PayResponse{
+		PaymentID: r.PaymentID,
+		OrderUUID: r.OrderUUID,
+		Status:    string(r.Status),
+	})
+}
+
+func (r IdempotencyRecord) ToDomainIdempotencyRecord() (domain.IdempotencyRecord, error) {
+	record := domain.IdempotencyRecord{
+		UserID:      r.UserID,
+		Key:         r.Key,
+		RequestHash: r.RequestHash,
+		OrderUUID:   r.OrderUUID,
+		CreatedAt:   r.CreatedAt,
+		CompletedAt: r.CompletedAt,
+	}
+
+	if r.Response != nil {
+		var resp PayResponse
+		if err := json.Unmarshal(r.Response, &resp); err != nil {
+			return domain.IdempotencyRecord{}, fmt.Errorf("failed to unmarshal stored response: %w", err)
+		}
+		record.Response = &domain.PayResult{
+			PaymentID: resp.PaymentID,
+			OrderUUID: resp.OrderUUID,
+			Status:    domain.PaymentStatus(resp.Status),
+		}
+	}
+
+	return record, nil
+}
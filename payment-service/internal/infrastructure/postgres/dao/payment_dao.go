@@ -1,6 +1,7 @@
 package dao
 
 import (
+	"database/sql"
 	"time"
 
 	"github.com/google/uuid"
@@ -9,26 +10,96 @@ import (
 )
 
 type Payment struct {
+	ID        int64     `db:"id"`
 	OrderUUID uuid.UUID `db:"order_uuid"`
 	UserID    int64     `db:"user_id"`
 	Amount    float64   `db:"amount"`
 	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+
+	RefundedAmount float64 `db:"refunded_amount"`
+
+	IntentID      uuid.NullUUID  `db:"intent_id"`
+	Status        string         `db:"status"`
+	FailureReason sql.NullString `db:"failure_reason"`
+	Provider      sql.NullString `db:"provider"`
+	ProviderRef   sql.NullString `db:"provider_ref"`
 }
 
 func FromDomainPayment(p domain.Payment) Payment {
 	return Payment{
-		OrderUUID: p.OrderUUID,
-		UserID:    p.UserID,
-		Amount:    p.Amount,
-		CreatedAt: p.CreatedAt,
+		ID:             p.ID,
+		OrderUUID:      p.OrderUUID,
+		UserID:         p.UserID,
+		Amount:         p.Amount,
+		RefundedAmount: p.RefundedAmount,
+		CreatedAt:      p.CreatedAt,
+		UpdatedAt:      p.UpdatedAt,
+		IntentID:       uuid.NullUUID{UUID: p.IntentID, Valid: p.IntentID != uuid.Nil},
+		Status:         string(p.Status),
+		FailureReason:  nullString(p.FailureReason),
+		Provider:       nullString(p.Provider),
+		ProviderRef:    nullString(p.ProviderRef),
 	}
 }
 
 func (p Payment) ToDomainPayment() domain.Payment {
 	return domain.Payment{
-		OrderUUID: p.OrderUUID,
-		UserID:    p.UserID,
-		Amount:    p.Amount,
-		CreatedAt: p.CreatedAt,
+		ID:             p.ID,
+		OrderUUID:      p.OrderUUID,
+		UserID:         p.UserID,
+		Amount:         p.Amount,
+		RefundedAmount: p.RefundedAmount,
+		CreatedAt:      p.CreatedAt,
+		UpdatedAt:      p.UpdatedAt,
+		IntentID:       p.IntentID.UUID,
+		Status:         domain.PaymentStatus(p.Status),
+		FailureReason:  p.FailureReason.String,
+		Provider:       p.Provider.String,
+		ProviderRef:    p.ProviderRef.String,
+	}
+}
+
+type PaymentAttempt struct {
+	ID            int64          `db:"id"`
+	PaymentID     int64          `db:"payment_id"`
+	Provider      string         `db:"provider"`
+	ProviderRef   string         `db:"provider_ref"`
+	Amount        float64        `db:"amount"`
+	Status        string         `db:"status"`
+	FailureReason sql.NullString `db:"failure_reason"`
+	CreatedAt     time.Time      `db:"created_at"`
+	UpdatedAt     time.Time      `db:"updated_at"`
+}
+
+func FromDomainPaymentAttempt(a domain.PaymentAttempt) PaymentAttempt {
+	return PaymentAttempt{
+		ID:            a.ID,
+		PaymentID:     a.PaymentID,
+		Provider:      a.Provider,
+		ProviderRef:   a.ProviderRef,
+		Amount:        a.Amount,
+		Status:        string(a.Status),
+		FailureReason: nullString(a.FailureReason),
+		CreatedAt:     a.CreatedAt,
+		UpdatedAt:     a.UpdatedAt,
+	}
+}
+
+func (a PaymentAttempt) ToDomainPaymentAttempt() domain.PaymentAttempt {
+	return domain.PaymentAttempt{
+		ID:            a.ID,
+		PaymentID:     a.PaymentID,
+		Provider:      a.Provider,
+		ProviderRef:   a.ProviderRef,
+		Amount:        a.Amount,
+		Status:        domain.PaymentStatus(a.Status),
+		FailureReason: a.FailureReason.String,
+		CreatedAt:     a.CreatedAt,
+		UpdatedAt:     a.UpdatedAt,
 	}
 }
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
@@ -0,0 +1,40 @@
+package dao
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Wrestler094/scalable-ecommerce-platform/payment-service/internal/domain"
+)
+
+type PaymentIntent struct {
+	ID          uuid.UUID `db:"id"`
+	OrderUUID   uuid.UUID `db:"order_uuid"`
+	UserID      int64     `db:"user_id"`
+	Amount      float64   `db:"amount"`
+	CheckoutURL string    `db:"checkout_url"`
+	CreatedAt   time.Time `db:"created_at"`
+}
+
+func FromDomainPaymentIntent(i domain.PaymentIntent) PaymentIntent {
+	return PaymentIntent{
+		ID:          i.ID,
+		OrderUUID:   i.OrderUUID,
+		UserID:      i.UserID,
+		Amount:      i.Amount,
+		CheckoutURL: i.CheckoutURL,
+		CreatedAt:   i.CreatedAt,
+	}
+}
+
+func (i PaymentIntent) ToDomainPaymentIntent() domain.PaymentIntent {
+	return domain.PaymentIntent{
+		ID:          i.ID,
+		OrderUUID:   i.OrderUUID,
+		UserID:      i.UserID,
+		Amount:      i.Amount,
+		CheckoutURL: i.CheckoutURL,
+		CreatedAt:   i.CreatedAt,
+	}
+}
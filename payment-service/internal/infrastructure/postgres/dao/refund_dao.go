@@ -0,0 +1,60 @@
+package dao
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/Wrestler094/scalable-ecommerce-platform/payment-service/internal/domain"
+)
+
+type Refund struct {
+	ID             int64          `db:"id"`
+	PaymentID      int64          `db:"payment_id"`
+	Amount         float64        `db:"amount"`
+	Status         string         `db:"status"`
+	Source         string         `db:"source"`
+	Reason         string         `db:"reason"`
+	IdempotencyKey string         `db:"idempotency_key"`
+	ReturnUUID     sql.NullString `db:"return_uuid"`
+	RequestedBy    sql.NullInt64  `db:"requested_by"`
+	ProviderRef    sql.NullString `db:"provider_ref"`
+	FailureReason  sql.NullString `db:"failure_reason"`
+	CreatedAt      time.Time      `db:"created_at"`
+	UpdatedAt      time.Time      `db:"updated_at"`
+}
+
+func FromDomainRefund(r domain.Refund) Refund {
+	return Refund{
+		ID:             r.ID,
+		PaymentID:      r.PaymentID,
+		Amount:         r.Amount,
+		Status:         string(r.Status),
+		Source:         string(r.Source),
+		Reason:         r.Reason,
+		IdempotencyKey: r.IdempotencyKey,
+		ReturnUUID:     nullString(r.ReturnUUID),
+		RequestedBy:    sql.NullInt64{Int64: r.RequestedBy, Valid: r.RequestedBy != 0},
+		ProviderRef:    nullString(r.ProviderRef),
+		FailureReason:  nullString(r.FailureReason),
+		CreatedAt:      r.CreatedAt,
+		UpdatedAt:      r.UpdatedAt,
+	}
+}
+
+func (r Refund) ToDomainRefund() domain.Refund {
+	return domain.Refund{
+		ID:             r.ID,
+		PaymentID:      r.PaymentID,
+		Amount:         r.Amount,
+		Status:         domain.RefundStatus(r.Status),
+		Source:         domain.RefundSource(r.Source),
+		Reason:         r.Reason,
+		IdempotencyKey: r.IdempotencyKey,
+		ReturnUUID:     r.ReturnUUID.String,
+		RequestedBy:    r.RequestedBy.Int64,
+		ProviderRef:    r.ProviderRef.String,
+		FailureReason:  r.FailureReason.String,
+		CreatedAt:      r.CreatedAt,
+		UpdatedAt:      r.UpdatedAt,
+	}
+}
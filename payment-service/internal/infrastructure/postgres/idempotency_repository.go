@@ -0,0 +1,112 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/Wrestler094/scalable-ecommerce-platform/payment-service/internal/domain"
+	"github.com/Wrestler094/scalable-ecommerce-platform/payment-service/internal/infrastructure/postgres/dao"
+)
+
+var _ domain.IdempotencyRepository = (*IdempotencyRepository)(nil)
+
+type IdempotencyRepository struct {
+	db *sqlx.DB
+}
+
+func NewIdempotencyRepository(db *sqlx.DB) *IdempotencyRepository {
+	return &IdempotencyRepository{db: db}
+}
+
+func (r *IdempotencyRepository) Reserve(ctx context.Context, record domain.IdempotencyRecord, staleAfter time.Duration) error {
+	const op = "idempotencyRepository.Reserve"
+
+	// Конкурентная вставка того же ключа ждёт на блокировке строки, поэтому брошенную запись
+	// перехватывает только один запрос: для остальных она уже не устаревшая
+	res, err := queryer(ctx, r.db).ExecContext(ctx, `
+		INSERT INTO idempotency_keys (user_id, key, request_hash, order_uuid)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id, key) DO UPDATE
+		SET created_at = now()
+		WHERE idempotency_keys.completed_at IS NULL
+		  AND idempotency_keys.request_hash = EXCLUDED.request_hash
+		  AND idempotency_keys.created_at < now() - $5 * interval '1 millisecond'
+	`, record.UserID, record.Key, record.RequestHash, record.OrderUUID, staleAfter.Milliseconds())
+	if err != nil {
+		return fmt.Errorf("%s: failed to insert idempotency key: %w", op, err)
+	}
+
+	count, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: failed to get affected rows: %w", op, err)
+	}
+
+	if count == 0 {
+		return fmt.Errorf("%s: %w", op, domain.ErrIdempotencyKeyExists)
+	}
+
+	return nil
+}
+
+func (r *IdempotencyRepository) Find(ctx context.Context, userID int64, key string) (domain.IdempotencyRecord, error) {
+	const op = "idempotencyRepository.Find"
+
+	var row dao.IdempotencyRecord
+	err := sqlx.GetContext(ctx, queryer(ctx, r.db), &row, `
+		SELECT user_id, key, request_hash, order_uuid, response, created_at, completed_at
+		FROM idempotency_keys
+		WHERE user_id = $1 AND key = $2
+	`, userID, key)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.IdempotencyRecord{}, fmt.Errorf("%s: %w", op, domain.ErrIdempotencyKeyNotFound)
+		}
+		return domain.IdempotencyRecord{}, fmt.Errorf("%s: failed to fetch idempotency key: %w", op, err)
+	}
+
+	record, err := row.ToDomainIdempotencyRecord()
+	if err != nil {
+		return domain.IdempotencyRecord{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return record, nil
+}
+
+func (r *IdempotencyRepository) Complete(ctx context.Context, userID int64, key string, result domain.PayResult) error {
+	const op = "idempotencyRepository.Complete"
+
+	response, err := dao.FromDomainPayResult(result)
+	if err != nil {
+		return fmt.Errorf("%s: failed to marshal response: %w", op, err)
+	}
+
+	_, err = queryer(ctx, r.db).ExecContext(ctx, `
+		UPDATE idempotency_keys
+		SET response = $3, completed_at = now()
+		WHERE user_id = $1 AND key = $2
+	`, userID, key, response)
+	if err != nil {
+		return fmt.Errorf("%s: failed to complete idempotency key: %w", op, err)
+	}
+
+	return nil
+}
+
+func (r *IdempotencyRepository) Release(ctx context.Context, userID int64, key string) error {
+	const op = "idempotencyRepository.Release"
+
+	_, err := queryer(ctx, r.db).ExecContext(ctx, `
+		DELETE FROM idempotency_keys
+		WHERE user_id = $1 AND key = $2 AND completed_at IS NULL
+	`, userID, key)
+	if err != nil {
+		return fmt.Errorf("%s: failed to release idempotency key: %w", op, err)
+	}
+
+	return nil
+}
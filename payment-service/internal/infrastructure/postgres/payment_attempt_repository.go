@@ -0,0 +1,106 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/Wrestler094/scalable-ecommerce-platform/payment-service/internal/domain"
+	"github.com/Wrestler094/scalable-ecommerce-platform/payment-service/internal/infrastructure/postgres/dao"
+	"github.com/Wrestler094/scalable-ecommerce-platform/payment-service/internal/infrastructure/txmanager"
+)
+
+const selectPaymentAttempts = `
+	SELECT id, payment_id, provider, provider_ref, amount, status, failure_reason, created_at, updated_at
+	FROM payment_attempts
+`
+
+type paymentAttemptRepository struct {
+	db *sqlx.DB
+}
+
+func NewPaymentAttemptRepository(db *sqlx.DB) domain.PaymentAttemptRepository {
+	return &paymentAttemptRepository{db: db}
+}
+
+func (r *paymentAttemptRepository) Create(ctx context.Context, a domain.PaymentAttempt) error {
+	const op = "paymentAttemptRepository.Create"
+	const query = `
+		INSERT INTO payment_attempts (payment_id, provider, provider_ref, amount, status, created_at, updated_at)
+		VALUES (:payment_id, :provider, :provider_ref, :amount, :status, :created_at, :created_at)
+	`
+
+	if _, err := sqlx.NamedExecContext(ctx, queryer(ctx, r.db), query, dao.FromDomainPaymentAttempt(a)); err != nil {
+		return fmt.Errorf("%s: failed to create payment attempt: %w", op, err)
+	}
+
+	return nil
+}
+
+func (r *paymentAttemptRepository) FindByProviderRef(ctx context.Context, providerRef string) (domain.PaymentAttempt, error) {
+	const op = "paymentAttemptRepository.FindByProviderRef"
+
+	query := selectPaymentAttempts + " WHERE provider_ref = $1"
+	if _, ok := txmanager.ExtractTx(ctx); ok {
+		query += " FOR UPDATE"
+	}
+
+	var row dao.PaymentAttempt
+	if err := sqlx.GetContext(ctx, queryer(ctx, r.db), &row, query, providerRef); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.PaymentAttempt{}, fmt.Errorf("%s: %w", op, domain.ErrPaymentNotFound)
+		}
+		return domain.PaymentAttempt{}, fmt.Errorf("%s: failed to get payment attempt: %w", op, err)
+	}
+
+	return row.ToDomainPaymentAttempt(), nil
+}
+
+func (r *paymentAttemptRepository) ListByPaymentIDs(ctx context.Context, paymentIDs []int64) ([]domain.PaymentAttempt, error) {
+	const op = "paymentAttemptRepository.ListByPaymentIDs"
+
+	if len(paymentIDs) == 0 {
+		return nil, nil
+	}
+
+	var rows []dao.PaymentAttempt
+	query := selectPaymentAttempts + " WHERE payment_id = ANY($1) ORDER BY payment_id, id"
+	if err := sqlx.SelectContext(ctx, queryer(ctx, r.db), &rows, query, pq.Array(paymentIDs)); err != nil {
+		return nil, fmt.Errorf("%s: failed to list payment attempts: %w", op, err)
+	}
+
+	attempts := make([]domain.PaymentAttempt, 0, len(rows))
+	for _, row := range rows {
+		attempts = append(attempts, row.ToDomainPaymentAttempt())
+	}
+
+	return attempts, nil
+}
+
+func (r *paymentAttemptRepository) Update(ctx context.Context, a domain.PaymentAttempt) error {
+	const op = "paymentAttemptRepository.Update"
+	const query = `
+		UPDATE payment_attempts
+		SET status = :status, failure_reason = :failure_reason, updated_at = now()
+		WHERE id = :id
+	`
+
+	res, err := sqlx.NamedExecContext(ctx, queryer(ctx, r.db), query, dao.FromDomainPaymentAttempt(a))
+	if err != nil {
+		return fmt.Errorf("%s: failed to update payment attempt: %w", op, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: failed to get affected rows: %w", op, err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("%s: %w", op, domain.ErrPaymentNotFound)
+	}
+
+	return nil
+}
@@ -0,0 +1,90 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/Wrestler094/scalable-ecommerce-platform/payment-service/internal/domain"
+	"github.com/Wrestler094/scalable-ecommerce-platform/payment-service/internal/infrastructure/postgres/dao"
+)
+
+type paymentIntentRepository struct {
+	db *sqlx.DB
+}
+
+func NewPaymentIntentRepository(db *sqlx.DB) domain.PaymentIntentRepository {
+	return &paymentIntentRepository{db: db}
+}
+
+func (r *paymentIntentRepository) CreateOrGet(ctx context.Context, intent domain.PaymentIntent) (domain.PaymentIntent, error) {
+	const op = "paymentIntentRepository.CreateOrGet"
+
+	// DO UPDATE с неизменяющим присваиванием нужен, чтобы RETURNING вернул существующую строку
+	const query = `
+		INSERT INTO payment_intents (id, order_uuid, user_id, amount, checkout_url, created_at)
+		VALUES (:id, :order_uuid, :user_id, :amount, :checkout_url, :created_at)
+		ON CONFLICT (order_uuid) DO UPDATE SET order_uuid = EXCLUDED.order_uuid
+		RETURNING id, order_uuid, user_id, amount, checkout_url, created_at
+	`
+
+	rows, err := r.db.NamedQueryContext(ctx, query, dao.FromDomainPaymentIntent(intent))
+	if err != nil {
+		return domain.PaymentIntent{}, fmt.Errorf("%s: failed to save payment intent: %w", op, err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return domain.PaymentIntent{}, fmt.Errorf("%s: no payment intent returned", op)
+	}
+
+	var saved dao.PaymentIntent
+	if err := rows.StructScan(&saved); err != nil {
+		return domain.PaymentIntent{}, fmt.Errorf("%s: failed to scan payment intent: %w", op, err)
+	}
+
+	return saved.ToDomainPaymentIntent(), nil
+}
+
+func (r *paymentIntentRepository) FindByID(ctx context.Context, id uuid.UUID) (domain.PaymentIntent, error) {
+	const op = "paymentIntentRepository.FindByID"
+
+	intent, err := r.findOne(ctx, "id = $1", id)
+	if err != nil {
+		return domain.PaymentIntent{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return intent, nil
+}
+
+func (r *paymentIntentRepository) FindByOrderUUID(ctx context.Context, orderUUID uuid.UUID) (domain.PaymentIntent, error) {
+	const op = "paymentIntentRepository.FindByOrderUUID"
+
+	intent, err := r.findOne(ctx, "order_uuid = $1", orderUUID)
+	if err != nil {
+		return domain.PaymentIntent{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return intent, nil
+}
+
+func (r *paymentIntentRepository) findOne(ctx context.Context, where string, arg any) (domain.PaymentIntent, error) {
+	query := `
+		SELECT id, order_uuid, user_id, amount, checkout_url, created_at
+		FROM payment_intents
+		WHERE ` + where
+
+	var row dao.PaymentIntent
+	if err := sqlx.GetContext(ctx, queryer(ctx, r.db), &row, query, arg); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.PaymentIntent{}, domain.ErrPaymentIntentNotFound
+		}
+		return domain.PaymentIntent{}, fmt.Errorf("failed to get payment intent: %w", err)
+	}
+
+	return row.ToDomainPaymentIntent(), nil
+}
@@ -2,15 +2,26 @@ package postgres
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 
+	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 
 	"github.com/Wrestler094/scalable-ecommerce-platform/payment-service/internal/domain"
 	"github.com/Wrestler094/scalable-ecommerce-platform/payment-service/internal/infrastructure/postgres/dao"
 	"github.com/Wrestler094/scalable-ecommerce-platform/payment-service/internal/infrastructure/txmanager"
 )
 
+const pgErrCodeUniqueViolation = "23505"
+
+const selectPayments = `
+	SELECT id, order_uuid, user_id, amount, refunded_amount, intent_id, status, failure_reason, provider, provider_ref, created_at, updated_at
+	FROM payments
+`
+
 type paymentRepository struct {
 	db *sqlx.DB
 }
@@ -19,28 +30,141 @@ func NewPaymentRepository(db *sqlx.DB) domain.PaymentRepository {
 	return &paymentRepository{db: db}
 }
 
-func (r *paymentRepository) Create(ctx context.Context, p domain.Payment) error {
+func (r *paymentRepository) Create(ctx context.Context, p domain.Payment) (int64, error) {
 	const op = "paymentRepository.Create"
 	const query = `
-		INSERT INTO payments (order_uuid, user_id, amount, created_at)
-		VALUES ($1, $2, $3, $4)
+		INSERT INTO payments (order_uuid, user_id, amount, intent_id, status, provider, provider_ref, created_at, updated_at)
+		VALUES (:order_uuid, :user_id, :amount, :intent_id, :status, :provider, :provider_ref, :created_at, :created_at)
+		RETURNING id
 	`
 
-	daoPayment := dao.FromDomainPayment(p)
+	rows, err := sqlx.NamedQueryContext(ctx, queryer(ctx, r.db), query, dao.FromDomainPayment(p))
+	if isUniqueViolation(err) {
+		return 0, fmt.Errorf("%s: %w", op, domain.ErrOrderAlreadyPaid)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("%s: failed to create payment: %w", op, err)
+	}
+	defer rows.Close()
 
-	// Пытаемся получить транзакцию из контекста
-	if tx, ok := txmanager.ExtractTx(ctx); ok {
-		_, err := tx.ExecContext(ctx, query, daoPayment.OrderUUID, daoPayment.UserID, daoPayment.Amount, daoPayment.CreatedAt)
-		if err != nil {
-			return fmt.Errorf("%s: failed to create payment in transaction: %w", op, err)
+	if !rows.Next() {
+		if err := rows.Err(); isUniqueViolation(err) {
+			return 0, fmt.Errorf("%s: %w", op, domain.ErrOrderAlreadyPaid)
 		}
-		return nil
+		return 0, fmt.Errorf("%s: no payment id returned", op)
+	}
+
+	var id int64
+	if err := rows.Scan(&id); err != nil {
+		return 0, fmt.Errorf("%s: failed to scan payment id: %w", op, err)
 	}
 
-	// Если транзакции нет - используем обычное соединение
-	_, err := r.db.ExecContext(ctx, query, daoPayment.OrderUUID, daoPayment.UserID, daoPayment.Amount, daoPayment.CreatedAt)
+	return id, nil
+}
+
+func (r *paymentRepository) FindByID(ctx context.Context, id int64) (domain.Payment, error) {
+	const op = "paymentRepository.FindByID"
+
+	payment, err := r.findOne(ctx, "id = $1", id)
 	if err != nil {
-		return fmt.Errorf("%s: failed to create payment: %w", op, err)
+		return domain.Payment{}, fmt.Errorf("%s: %w", op, err)
 	}
+
+	return payment, nil
+}
+
+func (r *paymentRepository) FindByOrderUUID(ctx context.Context, orderUUID uuid.UUID) (domain.Payment, error) {
+	const op = "paymentRepository.FindByOrderUUID"
+
+	payment, err := r.findOne(ctx, "order_uuid = $1", orderUUID)
+	if err != nil {
+		return domain.Payment{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return payment, nil
+}
+
+func (r *paymentRepository) FindByProviderRef(ctx context.Context, providerRef string) (domain.Payment, error) {
+	const op = "paymentRepository.FindByProviderRef"
+
+	payment, err := r.findOne(ctx, "id = (SELECT payment_id FROM payment_attempts WHERE provider_ref = $1)", providerRef)
+	if err != nil {
+		return domain.Payment{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return payment, nil
+}
+
+// findOne читает платёж по условию. Внутри транзакции строка блокируется,
+// чтобы вебхук и повторная оплата не меняли платёж одновременно.
+func (r *paymentRepository) findOne(ctx context.Context, where string, arg any) (domain.Payment, error) {
+	query := selectPayments + " WHERE " + where
+
+	if _, ok := txmanager.ExtractTx(ctx); ok {
+		query += " FOR UPDATE"
+	}
+
+	var row dao.Payment
+	if err := sqlx.GetContext(ctx, queryer(ctx, r.db), &row, query, arg); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Payment{}, domain.ErrPaymentNotFound
+		}
+		return domain.Payment{}, fmt.Errorf("failed to get payment: %w", err)
+	}
+
+	return row.ToDomainPayment(), nil
+}
+
+func (r *paymentRepository) ListByOrderUUID(ctx context.Context, orderUUID uuid.UUID) ([]domain.Payment, error) {
+	const op = "paymentRepository.ListByOrderUUID"
+
+	var rows []dao.Payment
+	if err := sqlx.SelectContext(ctx, queryer(ctx, r.db), &rows, selectPayments+" WHERE order_uuid = $1 ORDER BY id", orderUUID); err != nil {
+		return nil, fmt.Errorf("%s: failed to list payments: %w", op, err)
+	}
+
+	payments := make([]domain.Payment, 0, len(rows))
+	for _, row := range rows {
+		payments = append(payments, row.ToDomainPayment())
+	}
+
+	return payments, nil
+}
+
+func (r *paymentRepository) Update(ctx context.Context, p domain.Payment) error {
+	const op = "paymentRepository.Update"
+	const query = `
+		UPDATE payments
+		SET amount = :amount, refunded_amount = :refunded_amount, intent_id = :intent_id, status = :status, failure_reason = :failure_reason,
+		    provider = :provider, provider_ref = :provider_ref, updated_at = now()
+		WHERE id = :id
+	`
+
+	res, err := sqlx.NamedExecContext(ctx, queryer(ctx, r.db), query, dao.FromDomainPayment(p))
+	if err != nil {
+		return fmt.Errorf("%s: failed to update payment: %w", op, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: failed to get affected rows: %w", op, err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("%s: %w", op, domain.ErrPaymentNotFound)
+	}
+
 	return nil
 }
+
+// queryer возвращает транзакцию из контекста, если она есть, иначе — пул соединений.
+func queryer(ctx context.Context, db *sqlx.DB) sqlx.ExtContext {
+	if tx, ok := txmanager.ExtractTx(ctx); ok {
+		return tx
+	}
+	return db
+}
+
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == pgErrCodeUniqueViolation
+}
@@ -0,0 +1,183 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/Wrestler094/scalable-ecommerce-platform/payment-service/internal/domain"
+	"github.com/Wrestler094/scalable-ecommerce-platform/payment-service/internal/infrastructure/postgres/dao"
+	"github.com/Wrestler094/scalable-ecommerce-platform/payment-service/internal/infrastructure/txmanager"
+)
+
+const selectRefunds = `
+	SELECT id, payment_id, amount, status, source, reason, idempotency_key, return_uuid,
+	       requested_by, provider_ref, failure_reason, created_at, updated_at
+	FROM refunds
+`
+
+type refundRepository struct {
+	db *sqlx.DB
+}
+
+func NewRefundRepository(db *sqlx.DB) domain.RefundRepository {
+	return &refundRepository{db: db}
+}
+
+func (r *refundRepository) Create(ctx context.Context, refund domain.Refund) (int64, error) {
+	const op = "refundRepository.Create"
+	const query = `
+		INSERT INTO refunds (payment_id, amount, status, source, reason, idempotency_key, return_uuid, requested_by, created_at, updated_at)
+		VALUES (:payment_id, :amount, :status, :source, :reason, :idempotency_key, :return_uuid, :requested_by, :created_at, :created_at)
+		RETURNING id
+	`
+
+	rows, err := sqlx.NamedQueryContext(ctx, queryer(ctx, r.db), query, dao.FromDomainRefund(refund))
+	if isUniqueViolation(err) {
+		// Параллельный запрос с тем же ключом успел создать возврат первым
+		return 0, fmt.Errorf("%s: %w", op, domain.ErrRefundInProgress)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("%s: failed to create refund: %w", op, err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return 0, fmt.Errorf("%s: failed to create refund: %w", op, err)
+		}
+		return 0, fmt.Errorf("%s: no refund id returned", op)
+	}
+
+	var id int64
+	if err := rows.Scan(&id); err != nil {
+		return 0, fmt.Errorf("%s: failed to scan refund id: %w", op, err)
+	}
+
+	return id, nil
+}
+
+func (r *refundRepository) FindByIdempotencyKey(ctx context.Context, key string) (domain.Refund, error) {
+	const op = "refundRepository.FindByIdempotencyKey"
+
+	refund, err := r.findOne(ctx, "idempotency_key = $1", key)
+	if err != nil {
+		return domain.Refund{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return refund, nil
+}
+
+func (r *refundRepository) FindByID(ctx context.Context, id int64) (domain.Refund, error) {
+	const op = "refundRepository.FindByID"
+
+	refund, err := r.findOne(ctx, "id = $1", id)
+	if err != nil {
+		return domain.Refund{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return refund, nil
+}
+
+func (r *refundRepository) findOne(ctx context.Context, where string, arg any) (domain.Refund, error) {
+	query := selectRefunds + " WHERE " + where
+
+	if _, ok := txmanager.ExtractTx(ctx); ok {
+		query += " FOR UPDATE"
+	}
+
+	var row dao.Refund
+	if err := sqlx.GetContext(ctx, queryer(ctx, r.db), &row, query, arg); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Refund{}, domain.ErrRefundNotFound
+		}
+		return domain.Refund{}, fmt.Errorf("failed to get refund: %w", err)
+	}
+
+	return row.ToDomainRefund(), nil
+}
+
+func (r *refundRepository) PendingAmount(ctx context.Context, paymentID int64) (float64, error) {
+	const op = "refundRepository.PendingAmount"
+	const query = `SELECT COALESCE(SUM(amount), 0) FROM refunds WHERE payment_id = $1 AND status = $2`
+
+	var amount float64
+	if err := sqlx.GetContext(ctx, queryer(ctx, r.db), &amount, query, paymentID, domain.RefundStatusPending); err != nil {
+		return 0, fmt.Errorf("%s: failed to sum pending refunds: %w", op, err)
+	}
+
+	return amount, nil
+}
+
+func (r *refundRepository) ListByPaymentIDs(ctx context.Context, paymentIDs []int64) ([]domain.Refund, error) {
+	const op = "refundRepository.ListByPaymentIDs"
+
+	if len(paymentIDs) == 0 {
+		return nil, nil
+	}
+
+	var rows []dao.Refund
+	query := selectRefunds + " WHERE payment_id = ANY($1) ORDER BY payment_id, id"
+	if err := sqlx.SelectContext(ctx, queryer(ctx, r.db), &rows, query, pq.Array(paymentIDs)); err != nil {
+		return nil, fmt.Errorf("%s: failed to list refunds: %w", op, err)
+	}
+
+	refunds := make([]domain.Refund, 0, len(rows))
+	for _, row := range rows {
+		refunds = append(refunds, row.ToDomainRefund())
+	}
+
+	return refunds, nil
+}
+
+func (r *refundRepository) ListStalePending(ctx context.Context, staleAfter time.Duration, limit int) ([]domain.Refund, error) {
+	const op = "refundRepository.ListStalePending"
+
+	var rows []dao.Refund
+	query := selectRefunds + `
+		WHERE status = $1 AND updated_at < now() - $2 * interval '1 millisecond'
+		ORDER BY updated_at
+		LIMIT $3
+	`
+	err := sqlx.SelectContext(ctx, queryer(ctx, r.db), &rows, query, domain.RefundStatusPending, staleAfter.Milliseconds(), limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to list pending refunds: %w", op, err)
+	}
+
+	refunds := make([]domain.Refund, 0, len(rows))
+	for _, row := range rows {
+		refunds = append(refunds, row.ToDomainRefund())
+	}
+
+	return refunds, nil
+}
+
+func (r *refundRepository) Update(ctx context.Context, refund domain.Refund) error {
+	const op = "refundRepository.Update"
+	const query = `
+		UPDATE refunds
+		SET amount = :amount, status = :status, reason = :reason, provider_ref = :provider_ref,
+		    failure_reason = :failure_reason, updated_at = now()
+		WHERE id = :id
+	`
+
+	res, err := sqlx.NamedExecContext(ctx, queryer(ctx, r.db), query, dao.FromDomainRefund(refund))
+	if err != nil {
+		return fmt.Errorf("%s: failed to update refund: %w", op, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: failed to get affected rows: %w", op, err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("%s: %w", op, domain.ErrRefundNotFound)
+	}
+
+	return nil
+}
@@ -0,0 +1,285 @@
+package fake
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Wrestler094/scalable-ecommerce-platform/pkg/logger"
+
+	"github.com/Wrestler094/scalable-ecommerce-platform/payment-service/internal/domain"
+	"github.com/Wrestler094/scalable-ecommerce-platform/payment-service/internal/infrastructure/provider"
+)
+
+// Name — идентификатор встроенного тестового провайдера
+const Name = "fake"
+
+const (
+	webhookTimeout     = 5 * time.Second
+	webhookMaxAttempts = 3
+	webhookRetryDelay  = time.Second
+)
+
+// Outcome — итог списания, который разыгрывает тестовый провайдер.
+type Outcome string
+
+const (
+	OutcomeSucceed Outcome = "succeed"
+	OutcomeFail    Outcome = "fail"
+)
+
+// IsValid сообщает, является ли значение известным итогом списания.
+func (o Outcome) IsValid() bool {
+	return o == OutcomeSucceed || o == OutcomeFail
+}
+
+// Scenario описывает, чем закончится очередное списание и через сколько придёт вебхук.
+type Scenario struct {
+	Outcome       Outcome
+	Delay         time.Duration
+	FailureReason string
+}
+
+type Config struct {
+	// WebhookURL — адрес POST /payments/webhook, на который провайдер отправляет итог списания
+	WebhookURL string
+	Secret     string
+	// Default применяется, когда очередь сценариев пуста
+	Default Scenario
+}
+
+var _ domain.PaymentProvider = (*Provider)(nil)
+
+// Provider — встроенный тестовый провайдер. Списание всегда принимается, а итог
+// отправляется подписанным вебхуком по сценарию, как это делает настоящий эквайер.
+type Provider struct {
+	cfg    Config
+	client *http.Client
+	logger logger.Logger
+
+	mu      sync.Mutex
+	script  []Scenario
+	amounts map[string]float64
+	// refunds — ссылки выполненных возвратов по ключу идемпотентности
+	refunds map[string]string
+}
+
+func NewProvider(cfg Config, logger logger.Logger) (*Provider, error) {
+	const op = "fake.NewProvider"
+
+	// Неизвестный итог иначе незаметно разыгрывался бы как успешное списание
+	if !cfg.Default.Outcome.IsValid() {
+		return nil, fmt.Errorf("%s: unknown outcome %q, expected %q or %q", op, cfg.Default.Outcome, OutcomeSucceed, OutcomeFail)
+	}
+
+	return &Provider{
+		cfg:     cfg,
+		client:  &http.Client{Timeout: webhookTimeout},
+		logger:  logger,
+		amounts: make(map[string]float64),
+		refunds: make(map[string]string),
+	}, nil
+}
+
+// Script ставит сценарии в очередь: каждое следующее списание забирает первый из них.
+func (p *Provider) Script(scenarios ...Scenario) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.script = append(p.script, scenarios...)
+}
+
+func (p *Provider) Name() string {
+	return Name
+}
+
+func (p *Provider) CreateIntent(_ context.Context, req domain.ProviderIntentRequest) (domain.ProviderIntent, error) {
+	ref := "fake_pi_" + uuid.NewString()
+
+	p.mu.Lock()
+	p.amounts[ref] = req.Amount
+	p.mu.Unlock()
+
+	return domain.ProviderIntent{Ref: ref}, nil
+}
+
+func (p *Provider) Capture(_ context.Context, providerRef string) error {
+	const op = "fake.Provider.Capture"
+
+	p.mu.Lock()
+	amount, ok := p.amounts[providerRef]
+	delete(p.amounts, providerRef)
+	scenario := p.nextScenario()
+	p.mu.Unlock()
+
+	if !ok {
+		return fmt.Errorf("%s: unknown payment %s", op, providerRef)
+	}
+
+	event := webhookPayload{
+		ID:         "fake_evt_" + uuid.NewString(),
+		Type:       string(domain.WebhookPaymentCaptured),
+		PaymentRef: providerRef,
+		Amount:     amount,
+	}
+	if scenario.Outcome == OutcomeFail {
+		event.Type = string(domain.WebhookPaymentFailed)
+		event.FailureCode = scenario.FailureReason
+		if event.FailureCode == "" {
+			event.FailureCode = "card_declined"
+		}
+	}
+
+	// Вебхук уходит асинхронно и не зависит от контекста запроса, запустившего списание
+	go p.deliver(event, scenario.Delay)
+
+	return nil
+}
+
+// Cancel отменяет платёж, пока списание не запрошено. После Capture вебхук уже запланирован.
+func (p *Provider) Cancel(_ context.Context, providerRef string) error {
+	const op = "fake.Provider.Cancel"
+
+	p.mu.Lock()
+	_, ok := p.amounts[providerRef]
+	delete(p.amounts, providerRef)
+	p.mu.Unlock()
+
+	if !ok {
+		return fmt.Errorf("%s: payment %s: %w", op, providerRef, domain.ErrPaymentNotCancellable)
+	}
+
+	return nil
+}
+
+// Refund выполняется сразу. Повтор с тем же ключом возвращает уже выполненный возврат.
+func (p *Provider) Refund(_ context.Context, _ string, _ float64, idempotencyKey string) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if ref, ok := p.refunds[idempotencyKey]; ok {
+		return ref, nil
+	}
+
+	ref := "fake_re_" + uuid.NewString()
+	p.refunds[idempotencyKey] = ref
+	return ref, nil
+}
+
+func (p *Provider) GetRefund(_ context.Context, idempotencyKey string) (domain.ProviderRefund, error) {
+	const op = "fake.Provider.GetRefund"
+
+	p.mu.Lock()
+	ref, ok := p.refunds[idempotencyKey]
+	p.mu.Unlock()
+
+	if !ok {
+		return domain.ProviderRefund{}, fmt.Errorf("%s: %w", op, domain.ErrProviderRefundNotFound)
+	}
+
+	return domain.ProviderRefund{Ref: ref, Status: domain.RefundStatusSucceeded}, nil
+}
+
+func (p *Provider) ParseWebhook(payload []byte, signature string) (domain.WebhookEvent, error) {
+	const op = "fake.Provider.ParseWebhook"
+
+	if !provider.Verify([]byte(p.cfg.Secret), payload, signature) {
+		return domain.WebhookEvent{}, fmt.Errorf("%s: %w", op, domain.ErrInvalidWebhookSignature)
+	}
+
+	var event webhookPayload
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return domain.WebhookEvent{}, fmt.Errorf("%s: %w: %w", op, domain.ErrInvalidWebhookPayload, err)
+	}
+	if event.ID == "" || event.PaymentRef == "" {
+		return domain.WebhookEvent{}, fmt.Errorf("%s: missing event id or payment ref: %w", op, domain.ErrInvalidWebhookPayload)
+	}
+
+	return domain.WebhookEvent{
+		ID:            event.ID,
+		Type:          domain.WebhookEventType(event.Type),
+		ProviderRef:   event.PaymentRef,
+		Amount:        event.Amount,
+		FailureReason: event.FailureCode,
+		OccurredAt:    time.Unix(event.Created, 0).UTC(),
+	}, nil
+}
+
+// nextScenario вызывается под mu.
+func (p *Provider) nextScenario() Scenario {
+	if len(p.script) == 0 {
+		return p.cfg.Default
+	}
+
+	scenario := p.script[0]
+	p.script = p.script[1:]
+	return scenario
+}
+
+// webhookPayload — формат уведомления тестового провайдера
+type webhookPayload struct {
+	ID          string  `json:"id"`
+	Type        string  `json:"type"`
+	PaymentRef  string  `json:"payment_ref"`
+	Amount      float64 `json:"amount"`
+	FailureCode string  `json:"failure_code,omitempty"`
+	Created     int64   `json:"created"`
+}
+
+func (p *Provider) deliver(event webhookPayload, delay time.Duration) {
+	const op = "fake.Provider.deliver"
+
+	log := p.logger.WithOp(op).With("event_id", event.ID, "payment_ref", event.PaymentRef, "type", event.Type)
+
+	time.Sleep(delay)
+
+	event.Created = time.Now().Unix()
+	body, err := json.Marshal(event)
+	if err != nil {
+		log.WithError(err).Error("failed to marshal webhook")
+		return
+	}
+
+	for attempt := 1; attempt <= webhookMaxAttempts; attempt++ {
+		if err = p.send(body); err == nil {
+			return
+		}
+
+		log.WithError(err).Warn("webhook delivery failed", "attempt", attempt)
+		if attempt < webhookMaxAttempts {
+			time.Sleep(webhookRetryDelay * time.Duration(attempt))
+		}
+	}
+
+	log.WithError(err).Error("webhook delivery gave up")
+}
+
+func (p *Provider) send(body []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), webhookTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.cfg.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(provider.SignatureHeader, provider.Sign([]byte(p.cfg.Secret), body))
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	return nil
+}
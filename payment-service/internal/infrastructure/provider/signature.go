@@ -0,0 +1,33 @@
+package provider
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// SignatureHeader — заголовок, в котором провайдер передаёт подпись вебхука
+const SignatureHeader = "X-Webhook-Signature"
+
+// SignaturePrefix — схема подписи в заголовке вебхука: "sha256=<hex>"
+const SignaturePrefix = "sha256="
+
+// Sign подписывает тело вебхука HMAC-SHA256 общим секретом провайдера.
+func Sign(secret, payload []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(payload)
+	return SignaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify сравнивает подпись за постоянное время, чтобы не раскрывать её по времени ответа.
+func Verify(secret, payload []byte, signature string) bool {
+	got, err := hex.DecodeString(strings.TrimPrefix(signature, SignaturePrefix))
+	if err != nil || !strings.HasPrefix(signature, SignaturePrefix) {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(payload)
+	return hmac.Equal(got, mac.Sum(nil))
+}
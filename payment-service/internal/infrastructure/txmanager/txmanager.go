@@ -29,7 +29,7 @@ func (m *TxManager) WithinTx(ctx context.Context, fn func(ctx context.Context) e
 	}
 	defer func() {
 		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
-			m.logger.WithOp(op).Warn(fmt.Sprintf("%s: failed to rollback transaction: %w", op, err))
+			m.logger.WithOp(op).WithError(err).Warn("failed to rollback transaction")
 		}
 	}()
 
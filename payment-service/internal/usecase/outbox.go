@@ -0,0 +1,24 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Wrestler094/scalable-ecommerce-platform/pkg/outbox"
+)
+
+// writeOutboxEvent сериализует payload и записывает событие в outbox в рамках транзакции из ctx.
+func writeOutboxEvent(ctx context.Context, w outbox.Writer, topic, eventType string, payload any) error {
+	const op = "paymentUseCase.writeOutboxEvent"
+
+	event, err := outbox.NewEvent(topic, eventType, payload)
+	if err != nil {
+		return fmt.Errorf("%s: failed to build %s event: %w", op, eventType, err)
+	}
+
+	if err := w.Write(ctx, event); err != nil {
+		return fmt.Errorf("%s: failed to write %s event to outbox: %w", op, eventType, err)
+	}
+
+	return nil
+}
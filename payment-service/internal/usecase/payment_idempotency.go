@@ -0,0 +1,76 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Wrestler094/scalable-ecommerce-platform/payment-service/internal/domain"
+)
+
+// idempotencyInProgressTimeout — сколько незавершённый запрос считается выполняющимся.
+// После этого повтор возвращает текущее состояние платежа по заказу или, если платёж
+// не был записан, перехватывает ключ.
+const idempotencyInProgressTimeout = time.Minute
+
+// replayPayment возвращает результат исходного запроса с тем же ключом идемпотентности.
+func (uc *PaymentUseCase) replayPayment(ctx context.Context, key domain.IdempotencyRecord) (domain.PayResult, error) {
+	const op = "paymentUseCase.replayPayment"
+
+	record, err := uc.idempotencyRepo.Find(ctx, key.UserID, key.Key)
+	if err != nil {
+		return domain.PayResult{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if record.RequestHash != key.RequestHash {
+		return domain.PayResult{}, fmt.Errorf("%s: %w", op, domain.ErrIdempotencyKeyReused)
+	}
+
+	if record.Response != nil {
+		return *record.Response, nil
+	}
+
+	if time.Since(record.CreatedAt) < idempotencyInProgressTimeout {
+		return domain.PayResult{}, fmt.Errorf("%s: %w", op, domain.ErrIdempotencyRequestInProgress)
+	}
+
+	// Исходный запрос не сохранил ответ (например, сервис перезапустился). Если он успел
+	// записать платёж, возвращается его текущее состояние, иначе ключ можно занять заново
+	payment, err := uc.paymentRepo.FindByOrderUUID(ctx, record.OrderUUID)
+	if errors.Is(err, domain.ErrPaymentNotFound) {
+		return domain.PayResult{}, fmt.Errorf("%s: abandoned request: %w", op, domain.ErrIdempotencyKeyNotFound)
+	}
+	if err != nil {
+		return domain.PayResult{}, fmt.Errorf("%s: failed to get payment: %w", op, err)
+	}
+
+	return payResult(payment), nil
+}
+
+// fingerprintPayment вычисляет хеш запроса на оплату.
+func fingerprintPayment(cmd domain.PayCommand) string {
+	h := sha256.New()
+	_, _ = fmt.Fprintf(h, "%s|%.2f", cmd.OrderUUID, cmd.Amount)
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func payResult(p domain.Payment) domain.PayResult {
+	return domain.PayResult{
+		PaymentID: p.ID,
+		OrderUUID: p.OrderUUID,
+		Status:    p.Status,
+	}
+}
+
+// releaseKey освобождает ключ после ошибки, которую клиент может повторить с тем же ключом.
+func (uc *PaymentUseCase) releaseKey(ctx context.Context, cmd domain.PayCommand, cause error) error {
+	if err := uc.idempotencyRepo.Release(ctx, cmd.UserID, cmd.IdempotencyKey); err != nil {
+		return fmt.Errorf("%w (failed to release idempotency key: %v)", cause, err)
+	}
+
+	return cause
+}
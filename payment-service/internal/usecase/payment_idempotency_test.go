@@ -0,0 +1,40 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/Wrestler094/scalable-ecommerce-platform/payment-service/internal/domain"
+)
+
+func TestFingerprintPayment(t *testing.T) {
+	orderUUID := uuid.MustParse("5f0c7f38-52d6-4b8e-9a3b-8f0a1c2d3e4f")
+	base := domain.PayCommand{UserID: 1, OrderUUID: orderUUID, Amount: 99.9, IdempotencyKey: "key-1"}
+
+	tests := []struct {
+		name string
+		cmd  domain.PayCommand
+		same bool
+	}{
+		{"identical request", base, true},
+		{"another user and key", domain.PayCommand{UserID: 2, OrderUUID: orderUUID, Amount: 99.9, IdempotencyKey: "key-2"}, true},
+		{"same amount in cents", domain.PayCommand{OrderUUID: orderUUID, Amount: 99.900001}, true},
+		{"another order", domain.PayCommand{OrderUUID: uuid.MustParse("0b6f1d2c-3e4f-4a5b-8c7d-9e0f1a2b3c4d"), Amount: 99.9}, false},
+		{"another amount", domain.PayCommand{OrderUUID: orderUUID, Amount: 99.91}, false},
+	}
+
+	want := fingerprintPayment(base)
+	if len(want) != 64 {
+		t.Fatalf("fingerprint length = %d, want 64 hex chars", len(want))
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := fingerprintPayment(tt.cmd)
+			if (got == want) != tt.same {
+				t.Errorf("fingerprint equal = %v, want %v", got == want, tt.same)
+			}
+		})
+	}
+}
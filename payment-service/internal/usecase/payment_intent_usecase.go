@@ -0,0 +1,56 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Wrestler094/scalable-ecommerce-platform/payment-service/internal/domain"
+)
+
+var _ domain.PaymentIntentUseCase = (*PaymentIntentUseCase)(nil)
+
+type PaymentIntentUseCase struct {
+	intentRepo      domain.PaymentIntentRepository
+	checkoutBaseURL string
+}
+
+func NewPaymentIntentUseCase(intentRepo domain.PaymentIntentRepository, checkoutBaseURL string) *PaymentIntentUseCase {
+	return &PaymentIntentUseCase{
+		intentRepo:      intentRepo,
+		checkoutBaseURL: strings.TrimRight(checkoutBaseURL, "/"),
+	}
+}
+
+func (uc *PaymentIntentUseCase) CreatePaymentIntent(ctx context.Context, cmd domain.CreateIntentCommand) (domain.PaymentIntent, error) {
+	const op = "paymentIntentUseCase.CreatePaymentIntent"
+
+	if cmd.OrderUUID == uuid.Nil || cmd.UserID <= 0 || cmd.Amount <= 0 {
+		return domain.PaymentIntent{}, fmt.Errorf("%s: %w", op, domain.ErrInvalidPaymentIntent)
+	}
+
+	intentID := uuid.New()
+	intent := domain.PaymentIntent{
+		ID:          intentID,
+		OrderUUID:   cmd.OrderUUID,
+		UserID:      cmd.UserID,
+		Amount:      cmd.Amount,
+		CheckoutURL: fmt.Sprintf("%s/%s", uc.checkoutBaseURL, intentID),
+		CreatedAt:   time.Now().UTC(),
+	}
+
+	saved, err := uc.intentRepo.CreateOrGet(ctx, intent)
+	if err != nil {
+		return domain.PaymentIntent{}, fmt.Errorf("%s: failed to save payment intent: %w", op, err)
+	}
+
+	// Повторный вызов для заказа допустим только с теми же параметрами
+	if saved.UserID != cmd.UserID || saved.Amount != cmd.Amount {
+		return domain.PaymentIntent{}, fmt.Errorf("%s: %w", op, domain.ErrPaymentIntentMismatch)
+	}
+
+	return saved, nil
+}
@@ -2,87 +2,565 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/google/uuid"
 
 	"github.com/Wrestler094/scalable-ecommerce-platform/pkg/events"
+	"github.com/Wrestler094/scalable-ecommerce-platform/pkg/outbox"
 
 	"github.com/Wrestler094/scalable-ecommerce-platform/payment-service/internal/domain"
 )
 
+var _ domain.PaymentUseCase = (*PaymentUseCase)(nil)
+
+// captureFailedReason — причина отказа, если провайдер не принял списание
+const captureFailedReason = "payment provider rejected capture"
+
+// lateCaptureReason — причина возврата списания, пришедшего после отмены или истечения заказа
+const lateCaptureReason = "order is no longer payable"
+
 type PaymentUseCase struct {
 	paymentRepo     domain.PaymentRepository
-	outboxWriter    domain.OutboxWriter[events.PaymentSuccessfulPayload]
+	attemptRepo     domain.PaymentAttemptRepository
+	refundRepo      domain.RefundRepository
+	intentRepo      domain.PaymentIntentRepository
+	orderProvider   domain.OrderProvider
+	provider        domain.PaymentProvider
+	outboxWriter    outbox.Writer
 	idempotencyRepo domain.IdempotencyRepository
+	refunds         domain.RefundUseCase
 	txManager       domain.TxManager
 }
 
 func NewPaymentUseCase(
 	paymentRepo domain.PaymentRepository,
-	outbox domain.OutboxWriter[events.PaymentSuccessfulPayload],
+	attemptRepo domain.PaymentAttemptRepository,
+	refundRepo domain.RefundRepository,
+	intentRepo domain.PaymentIntentRepository,
+	orderProvider domain.OrderProvider,
+	provider domain.PaymentProvider,
+	outboxWriter outbox.Writer,
 	idempotencyRepo domain.IdempotencyRepository,
+	refunds domain.RefundUseCase,
 	txManager domain.TxManager,
 ) *PaymentUseCase {
 	return &PaymentUseCase{
 		paymentRepo:     paymentRepo,
-		outboxWriter:    outbox,
+		attemptRepo:     attemptRepo,
+		refundRepo:      refundRepo,
+		intentRepo:      intentRepo,
+		orderProvider:   orderProvider,
+		provider:        provider,
+		outboxWriter:    outboxWriter,
 		idempotencyRepo: idempotencyRepo,
+		refunds:         refunds,
 		txManager:       txManager,
 	}
 }
 
-func (uc *PaymentUseCase) ProcessPayment(ctx context.Context, cmd domain.PayCommand) error {
+// ProcessPayment запускает оплату заказа у провайдера. Итог оплаты приходит позже вебхуком,
+// поэтому здесь платёж только переводится в pending.
+func (uc *PaymentUseCase) ProcessPayment(ctx context.Context, cmd domain.PayCommand) (domain.PayResult, error) {
 	const op = "paymentUseCase.ProcessPayment"
 
-	// 1. Проверка идемпотентности ДО транзакции
-	exists, err := uc.idempotencyRepo.Exists(ctx, cmd.IdempotencyKey)
+	key := domain.IdempotencyRecord{
+		UserID:      cmd.UserID,
+		Key:         cmd.IdempotencyKey,
+		RequestHash: fingerprintPayment(cmd),
+		OrderUUID:   cmd.OrderUUID,
+	}
+
+	// 1. Повтор запроса возвращает сохранённый ответ
+	result, err := uc.replayPayment(ctx, key)
+	if !errors.Is(err, domain.ErrIdempotencyKeyNotFound) {
+		return result, err
+	}
+
+	// 2. Сверка платежа с заказом: сумму, владельца и статус задаёт order-service, а не клиент
+	order, err := uc.orderProvider.GetOrder(ctx, cmd.OrderUUID)
+	if err != nil {
+		return domain.PayResult{}, fmt.Errorf("%s: failed to get order: %w", op, err)
+	}
+	if err := verifyPayment(order, cmd); err != nil {
+		return domain.PayResult{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Намерение, выданное заказу при оформлении, привязывается к платежу
+	paymentIntent, err := uc.resolveIntent(ctx, cmd, order)
 	if err != nil {
-		return fmt.Errorf("%s: failed to check idempotency: %w", op, err)
+		return domain.PayResult{}, fmt.Errorf("%s: %w", op, err)
 	}
-	if exists {
-		return fmt.Errorf("%s: idempotency key already used, %w", op, domain.ErrDuplicatePayment)
+
+	// 3. Ключ занимается до обращения к провайдеру: параллельные повторы с тем же ключом
+	// не создают лишних платёжных намерений
+	err = uc.idempotencyRepo.Reserve(ctx, key, idempotencyInProgressTimeout)
+	if errors.Is(err, domain.ErrIdempotencyKeyExists) {
+		// Параллельный запрос с тем же ключом занял его раньше
+		return uc.replayPayment(ctx, key)
+	}
+	if err != nil {
+		return domain.PayResult{}, fmt.Errorf("%s: %w", op, err)
 	}
 
-	// 2. Всё бизнес-действие — внутри транзакции
+	// 4. Регистрация у провайдера — вне транзакции, чтобы внешний вызов не держал блокировки в БД
+	intent, err := uc.provider.CreateIntent(ctx, domain.ProviderIntentRequest{
+		OrderUUID:      cmd.OrderUUID,
+		Amount:         order.TotalAmount,
+		IdempotencyKey: cmd.IdempotencyKey,
+	})
+	if err != nil {
+		err = fmt.Errorf("%s: failed to create provider intent: %w: %w", op, domain.ErrPaymentProviderFailed, err)
+		return domain.PayResult{}, uc.releaseKey(ctx, cmd, err)
+	}
+
+	// 5. Платёж в created с новой попыткой
+	var payment domain.Payment
 	err = uc.txManager.WithinTx(ctx, func(txCtx context.Context) error {
-		payment := domain.Payment{
-			OrderUUID: cmd.OrderUUID,
-			UserID:    cmd.UserID,
-			Amount:    cmd.Amount,
-			CreatedAt: time.Now().UTC(),
-		}
+		var err error
+		payment, err = uc.startAttempt(txCtx, cmd, order, paymentIntent.ID, intent)
+		return err
+	})
+	if err != nil {
+		return domain.PayResult{}, uc.releaseKey(ctx, cmd, fmt.Errorf("%s: %w", op, err))
+	}
 
-		if err = uc.paymentRepo.Create(txCtx, payment); err != nil {
-			return fmt.Errorf("%s: failed to create payment: %w", op, err)
+	// 6. Списание: итог придёт вебхуком
+	if err := uc.provider.Capture(ctx, intent.Ref); err != nil {
+		// Списание не запущено — платёж сразу завершается неудачей, чтобы заказ можно было оплатить повторно.
+		// Отказ провайдера не окончательный результат, поэтому ключ освобождается для повтора
+		if failErr := uc.applyProviderResult(ctx, intent.Ref, domain.PaymentStatusFailed, captureFailedReason); failErr != nil {
+			return domain.PayResult{}, fmt.Errorf("%s: failed to mark payment as failed: %w", op, failErr)
 		}
+		err = fmt.Errorf("%s: failed to capture payment: %w: %w", op, domain.ErrPaymentProviderFailed, err)
+		return domain.PayResult{}, uc.releaseKey(ctx, cmd, err)
+	}
+
+	if err := uc.applyProviderResult(ctx, intent.Ref, domain.PaymentStatusPending, ""); err != nil {
+		return domain.PayResult{}, fmt.Errorf("%s: failed to mark payment as pending: %w", op, err)
+	}
+
+	// 7. Сохранение ответа для повторов. Платёж уже запущен, поэтому ошибка здесь не отменяет результат
+	payment.Status = domain.PaymentStatusPending
+	result = payResult(payment)
+	if err := uc.idempotencyRepo.Complete(ctx, cmd.UserID, cmd.IdempotencyKey, result); err != nil {
+		return result, fmt.Errorf("%s: %w: %w", op, domain.ErrIdempotencyRegistrationFailed, err)
+	}
 
-		// TODO: Подумать над тем, что передавать
-		event := domain.OutboxEvent[events.PaymentSuccessfulPayload]{
-			EventID:   uuid.New(),
-			EventType: events.EventPaymentSuccessful,
-			Timestamp: time.Now(),
-			Payload: events.PaymentSuccessfulPayload{
-				OrderUUID: cmd.OrderUUID.String(),
-				UserID:    cmd.UserID,
-				Amount:    cmd.Amount,
-			},
+	return result, nil
+}
+
+// startAttempt создаёт платёж или возвращает неудавшийся платёж в created и записывает новую попытку.
+// Вызывается внутри транзакции.
+func (uc *PaymentUseCase) startAttempt(
+	ctx context.Context,
+	cmd domain.PayCommand,
+	order domain.Order,
+	intentID uuid.UUID,
+	intent domain.ProviderIntent,
+) (domain.Payment, error) {
+	now := time.Now().UTC()
+
+	payment, err := uc.paymentRepo.FindByOrderUUID(ctx, cmd.OrderUUID)
+	switch {
+	case errors.Is(err, domain.ErrPaymentNotFound):
+		payment = domain.Payment{
+			OrderUUID:   cmd.OrderUUID,
+			UserID:      cmd.UserID,
+			Amount:      order.TotalAmount,
+			IntentID:    intentID,
+			CreatedAt:   now,
+			Status:      domain.PaymentStatusCreated,
+			Provider:    uc.provider.Name(),
+			ProviderRef: intent.Ref,
 		}
+		if payment.ID, err = uc.paymentRepo.Create(ctx, payment); err != nil {
+			return domain.Payment{}, err
+		}
+
+	case err != nil:
+		return domain.Payment{}, fmt.Errorf("failed to get payment: %w", err)
 
-		if err = uc.outboxWriter.Write(txCtx, event); err != nil {
-			return fmt.Errorf("%s: failed to write payment event to outbox: %w", op, err)
+	case payment.Status.IsCaptured():
+		return domain.Payment{}, domain.ErrOrderAlreadyPaid
+
+	case payment.Status.IsInProgress():
+		return domain.Payment{}, domain.ErrPaymentInProgress
+
+	default:
+		if err := payment.TransitionTo(domain.PaymentStatusCreated); err != nil {
+			return domain.Payment{}, err
+		}
+		payment.Amount = order.TotalAmount
+		payment.IntentID = intentID
+		payment.FailureReason = ""
+		payment.Provider = uc.provider.Name()
+		payment.ProviderRef = intent.Ref
+		if err := uc.paymentRepo.Update(ctx, payment); err != nil {
+			return domain.Payment{}, err
 		}
+	}
+
+	err = uc.attemptRepo.Create(ctx, domain.PaymentAttempt{
+		PaymentID:   payment.ID,
+		Provider:    payment.Provider,
+		ProviderRef: intent.Ref,
+		Amount:      payment.Amount,
+		Status:      domain.PaymentStatusCreated,
+		CreatedAt:   now,
+	})
+	if err != nil {
+		return domain.Payment{}, err
+	}
+
+	return payment, nil
+}
+
+// HandleProviderWebhook применяет уведомление провайдера к платежу.
+// Повторная доставка уже применённого уведомления ничего не меняет.
+func (uc *PaymentUseCase) HandleProviderWebhook(ctx context.Context, payload []byte, signature string) error {
+	const op = "paymentUseCase.HandleProviderWebhook"
 
+	event, err := uc.provider.ParseWebhook(payload, signature)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	switch event.Type {
+	case domain.WebhookPaymentAuthorized:
+		err = uc.applyProviderResult(ctx, event.ProviderRef, domain.PaymentStatusAuthorized, "")
+	case domain.WebhookPaymentCaptured:
+		err = uc.applyProviderResult(ctx, event.ProviderRef, domain.PaymentStatusCaptured, "")
+	case domain.WebhookPaymentFailed:
+		err = uc.applyProviderResult(ctx, event.ProviderRef, domain.PaymentStatusFailed, event.FailureReason)
+	default:
+		// Неизвестные уведомления подтверждаются, чтобы провайдер не присылал их повторно
 		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("%s: event %s: %w", op, event.ID, err)
+	}
+
+	return nil
+}
+
+// applyProviderResult переводит попытку и её платёж в статус to. Если попытка уже не может
+// перейти в этот статус, уведомление считается применённым ранее и игнорируется.
+// О списании и неудаче оплаты сообщается через outbox в той же транзакции.
+//
+// Списание может прийти уже после отмены или истечения заказа. Такой заказ order-service
+// оплатить не даст, поэтому payment_successful не публикуется, а списанное возвращается.
+// Повторная доставка того же вебхука повторяет возврат с тем же ключом идемпотентности.
+func (uc *PaymentUseCase) applyProviderResult(ctx context.Context, providerRef string, to domain.PaymentStatus, reason string) error {
+	var late bool
+	if to == domain.PaymentStatusCaptured {
+		var err error
+		if late, err = uc.isLateCapture(ctx, providerRef); err != nil {
+			return err
+		}
+	}
+
+	var payment domain.Payment
+	err := uc.txManager.WithinTx(ctx, func(txCtx context.Context) error {
+		// Платёж блокируется раньше попытки — в том же порядке, что и при старте оплаты
+		var err error
+		payment, err = uc.paymentRepo.FindByProviderRef(txCtx, providerRef)
+		if err != nil {
+			return err
+		}
+		attempt, err := uc.attemptRepo.FindByProviderRef(txCtx, providerRef)
+		if err != nil {
+			return err
+		}
+
+		if !attempt.Status.CanTransitionTo(to) {
+			return nil
+		}
+		if err := attempt.TransitionTo(to); err != nil {
+			return err
+		}
+		attempt.FailureReason = reason
+		if err := uc.attemptRepo.Update(txCtx, attempt); err != nil {
+			return err
+		}
+
+		if err := payment.TransitionTo(to); err != nil {
+			return err
+		}
+		payment.FailureReason = reason
+		if err := uc.paymentRepo.Update(txCtx, payment); err != nil {
+			return err
+		}
+
+		switch {
+		case to == domain.PaymentStatusCaptured && late:
+			return nil
+		case to == domain.PaymentStatusCaptured:
+			return writeOutboxEvent(txCtx, uc.outboxWriter, events.TopicPayments, events.EventPaymentSuccessful, events.PaymentSuccessfulPayload{
+				OrderUUID: payment.OrderUUID.String(),
+				UserID:    payment.UserID,
+				Amount:    payment.Amount,
+			})
+		case to == domain.PaymentStatusFailed:
+			return writeOutboxEvent(txCtx, uc.outboxWriter, events.TopicPayments, events.EventPaymentFailed, events.PaymentFailedPayload{
+				OrderUUID: payment.OrderUUID.String(),
+				UserID:    payment.UserID,
+				Amount:    payment.Amount,
+				Reason:    reason,
+			})
+		default:
+			return nil
+		}
 	})
 	if err != nil {
 		return err
 	}
 
-	// 3. Регистрируем идемпотентность в Redis (вне транзакции)
-	if err := uc.idempotencyRepo.Register(ctx, cmd.IdempotencyKey); err != nil {
-		return fmt.Errorf("%s: failed to register idempotency key: %w", op, domain.ErrIdempotencyRegistrationFailed)
+	if late {
+		return uc.refundLateCapture(ctx, payment)
+	}
+
+	return nil
+}
+
+// isLateCapture сообщает, что заказ платежа уже нельзя оплатить: его отменили
+// или он истёк, пока провайдер проводил списание.
+func (uc *PaymentUseCase) isLateCapture(ctx context.Context, providerRef string) (bool, error) {
+	payment, err := uc.paymentRepo.FindByProviderRef(ctx, providerRef)
+	if err != nil {
+		return false, err
+	}
+
+	order, err := uc.orderProvider.GetOrder(ctx, payment.OrderUUID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get order: %w", err)
+	}
+
+	return !order.Status.IsPayable() && !order.Status.IsPaid(), nil
+}
+
+// refundLateCapture возвращает списание по заказу, который уже нельзя оплатить.
+func (uc *PaymentUseCase) refundLateCapture(ctx context.Context, payment domain.Payment) error {
+	_, err := uc.refunds.RefundPayment(ctx, domain.RefundCommand{
+		PaymentID:      payment.ID,
+		Source:         domain.RefundSourceLateCapture,
+		Reason:         lateCaptureReason,
+		IdempotencyKey: "late_capture:" + strconv.FormatInt(payment.ID, 10),
+	})
+	switch {
+	case errors.Is(err, domain.ErrPaymentNotRefundable):
+		// Платёж уже вернули целиком, например по отмене оплаченного заказа
+		return nil
+	case errors.Is(err, domain.ErrRefundInProgress):
+		// Возврат по предыдущей доставке вебхука ещё выполняется
+		return nil
+	case err != nil:
+		return fmt.Errorf("failed to refund late capture: %w", err)
+	}
+
+	return nil
+}
+
+// CancelOrderPayment отменяет у провайдера оплату заказа, которую ещё можно отменить,
+// и завершает платёж неудачей без события payment_failed: заказ уже закрыт.
+// Если списание уже запущено, его итог придёт вебхуком и при успехе будет возвращён.
+// Уже проведённое списание возвращается сразу: оно зафиксировано, пока заказ ещё ждал оплаты,
+// но заказ закрыли раньше, чем order-service принял payment_successful.
+func (uc *PaymentUseCase) CancelOrderPayment(ctx context.Context, orderUUID uuid.UUID, reason string) error {
+	const op = "paymentUseCase.CancelOrderPayment"
+
+	payment, err := uc.paymentRepo.FindByOrderUUID(ctx, orderUUID)
+	if errors.Is(err, domain.ErrPaymentNotFound) {
+		// Заказ так и не начали оплачивать
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if payment.Status.IsCaptured() {
+		if err := uc.refundLateCapture(ctx, payment); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		return nil
+	}
+	if !payment.Status.IsInProgress() {
+		return nil
+	}
+
+	ref := payment.ProviderRef
+	err = uc.provider.Cancel(ctx, ref)
+	if errors.Is(err, domain.ErrPaymentNotCancellable) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w: %w", op, domain.ErrPaymentProviderFailed, err)
+	}
+
+	err = uc.txManager.WithinTx(ctx, func(txCtx context.Context) error {
+		// Платёж блокируется раньше попытки — в том же порядке, что и при старте оплаты
+		payment, err := uc.paymentRepo.FindByProviderRef(txCtx, ref)
+		if err != nil {
+			return err
+		}
+		attempt, err := uc.attemptRepo.FindByProviderRef(txCtx, ref)
+		if err != nil {
+			return err
+		}
+
+		// Вебхук мог завершить попытку, пока шла отмена у провайдера
+		if !attempt.Status.CanTransitionTo(domain.PaymentStatusFailed) {
+			return nil
+		}
+		if err := attempt.TransitionTo(domain.PaymentStatusFailed); err != nil {
+			return err
+		}
+		attempt.FailureReason = reason
+		if err := uc.attemptRepo.Update(txCtx, attempt); err != nil {
+			return err
+		}
+
+		if err := payment.TransitionTo(domain.PaymentStatusFailed); err != nil {
+			return err
+		}
+		payment.FailureReason = reason
+		return uc.paymentRepo.Update(txCtx, payment)
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// GetPayment возвращает платёж с историей попыток и возвратов владельцу платежа или администратору.
+func (uc *PaymentUseCase) GetPayment(ctx context.Context, id int64, requester domain.PaymentRequester) (domain.Payment, error) {
+	const op = "paymentUseCase.GetPayment"
+
+	payment, err := uc.paymentRepo.FindByID(ctx, id)
+	if err != nil {
+		return domain.Payment{}, fmt.Errorf("%s: %w", op, err)
+	}
+	if !requester.CanView(payment) {
+		return domain.Payment{}, fmt.Errorf("%s: %w", op, domain.ErrPaymentAccessDenied)
+	}
+
+	payments, err := uc.withHistory(ctx, []domain.Payment{payment})
+	if err != nil {
+		return domain.Payment{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return payments[0], nil
+}
+
+// ListPaymentsByOrder возвращает платежи заказа с историей попыток и возвратов владельцу заказа или администратору.
+func (uc *PaymentUseCase) ListPaymentsByOrder(ctx context.Context, orderUUID uuid.UUID, requester domain.PaymentRequester) ([]domain.Payment, error) {
+	const op = "paymentUseCase.ListPaymentsByOrder"
+
+	payments, err := uc.paymentRepo.ListByOrderUUID(ctx, orderUUID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	for _, payment := range payments {
+		if !requester.CanView(payment) {
+			return nil, fmt.Errorf("%s: %w", op, domain.ErrPaymentAccessDenied)
+		}
+	}
+
+	payments, err = uc.withHistory(ctx, payments)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return payments, nil
+}
+
+// withHistory дополняет платежи их попытками и возвратами.
+func (uc *PaymentUseCase) withHistory(ctx context.Context, payments []domain.Payment) ([]domain.Payment, error) {
+	ids := make([]int64, 0, len(payments))
+	for _, payment := range payments {
+		ids = append(ids, payment.ID)
+	}
+
+	attempts, err := uc.attemptRepo.ListByPaymentIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list payment attempts: %w", err)
+	}
+
+	attemptsByPayment := make(map[int64][]domain.PaymentAttempt, len(payments))
+	for _, attempt := range attempts {
+		attemptsByPayment[attempt.PaymentID] = append(attemptsByPayment[attempt.PaymentID], attempt)
+	}
+
+	refunds, err := uc.refundRepo.ListByPaymentIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list refunds: %w", err)
+	}
+
+	refundsByPayment := make(map[int64][]domain.Refund, len(payments))
+	for _, refund := range refunds {
+		refundsByPayment[refund.PaymentID] = append(refundsByPayment[refund.PaymentID], refund)
+	}
+
+	for i := range payments {
+		payments[i].Attempts = attemptsByPayment[payments[i].ID]
+		payments[i].Refunds = refundsByPayment[payments[i].ID]
+	}
+
+	return payments, nil
+}
+
+// verifyPayment проверяет, что пользователь оплачивает свой неоплаченный заказ на полную сумму.
+func verifyPayment(order domain.Order, cmd domain.PayCommand) error {
+	if order.UserID != cmd.UserID {
+		return domain.ErrOrderAccessDenied
+	}
+	if order.Status.IsPaid() {
+		return domain.ErrOrderAlreadyPaid
+	}
+	if !order.Status.IsPayable() {
+		return fmt.Errorf("order status %q: %w", order.Status, domain.ErrOrderNotPayable)
+	}
+	if !order.AmountMatches(cmd.Amount) {
+		return fmt.Errorf("expected %.2f, got %.2f: %w", order.TotalAmount, cmd.Amount, domain.ErrPaymentAmountMismatch)
+	}
+	return nil
+}
+
+// resolveIntent находит намерение оплаты заказа: по IntentID из checkout URL или по заказу.
+// Заказы, оформленные до появления намерений, оплачиваются без него — возвращается пустое намерение.
+func (uc *PaymentUseCase) resolveIntent(ctx context.Context, cmd domain.PayCommand, order domain.Order) (domain.PaymentIntent, error) {
+	if cmd.IntentID == uuid.Nil {
+		intent, err := uc.intentRepo.FindByOrderUUID(ctx, cmd.OrderUUID)
+		if errors.Is(err, domain.ErrPaymentIntentNotFound) {
+			return domain.PaymentIntent{}, nil
+		}
+		if err != nil {
+			return domain.PaymentIntent{}, fmt.Errorf("failed to get payment intent: %w", err)
+		}
+		return intent, verifyIntent(intent, order)
+	}
+
+	intent, err := uc.intentRepo.FindByID(ctx, cmd.IntentID)
+	if err != nil {
+		return domain.PaymentIntent{}, fmt.Errorf("failed to get payment intent: %w", err)
+	}
+	if intent.OrderUUID != cmd.OrderUUID {
+		return domain.PaymentIntent{}, domain.ErrPaymentIntentMismatch
+	}
+
+	return intent, verifyIntent(intent, order)
+}
+
+// verifyIntent сверяет намерение с заказом: оно выдано владельцу заказа на его сумму.
+func verifyIntent(intent domain.PaymentIntent, order domain.Order) error {
+	if intent.UserID != order.UserID {
+		return domain.ErrOrderAccessDenied
+	}
+	if !domain.AmountsEqual(intent.Amount, order.TotalAmount) {
+		return domain.ErrPaymentIntentMismatch
 	}
 
 	return nil
@@ -0,0 +1,286 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Wrestler094/scalable-ecommerce-platform/pkg/events"
+	"github.com/Wrestler094/scalable-ecommerce-platform/pkg/outbox"
+
+	"github.com/Wrestler094/scalable-ecommerce-platform/payment-service/internal/domain"
+)
+
+var _ domain.RefundUseCase = (*RefundUseCase)(nil)
+
+// refundFailedReason — причина неудачи, если провайдер не принял возврат
+const refundFailedReason = "payment provider rejected refund"
+
+// refundLostReason — причина неудачи, если провайдер так и не получил запрос на возврат
+const refundLostReason = "refund request did not reach payment provider"
+
+type RefundUseCase struct {
+	paymentRepo  domain.PaymentRepository
+	refundRepo   domain.RefundRepository
+	provider     domain.PaymentProvider
+	outboxWriter outbox.Writer
+	txManager    domain.TxManager
+}
+
+func NewRefundUseCase(
+	paymentRepo domain.PaymentRepository,
+	refundRepo domain.RefundRepository,
+	provider domain.PaymentProvider,
+	outboxWriter outbox.Writer,
+	txManager domain.TxManager,
+) *RefundUseCase {
+	return &RefundUseCase{
+		paymentRepo:  paymentRepo,
+		refundRepo:   refundRepo,
+		provider:     provider,
+		outboxWriter: outboxWriter,
+		txManager:    txManager,
+	}
+}
+
+// RefundPayment возвращает средства в три шага: сумма резервируется под блокировкой платежа,
+// затем возвращается через провайдера, после чего возврат фиксируется вместе с событием payment_refunded.
+// Резерв не даёт параллельным возвратам в сумме превысить списанное.
+func (uc *RefundUseCase) RefundPayment(ctx context.Context, cmd domain.RefundCommand) (domain.Refund, error) {
+	const op = "refundUseCase.RefundPayment"
+
+	// 1. Резервирование суммы
+	var (
+		payment  domain.Payment
+		refund   domain.Refund
+		replayed bool
+	)
+	err := uc.txManager.WithinTx(ctx, func(txCtx context.Context) error {
+		var err error
+		payment, refund, replayed, err = uc.reserve(txCtx, cmd)
+		return err
+	})
+	if err != nil {
+		return domain.Refund{}, fmt.Errorf("%s: %w", op, err)
+	}
+	if replayed {
+		return refund, nil
+	}
+
+	// 2. Возврат у провайдера — вне транзакции, чтобы внешний вызов не держал блокировку платежа
+	providerRef, err := uc.provider.Refund(ctx, payment.ProviderRef, refund.Amount, refund.IdempotencyKey)
+	if err != nil {
+		refund.Status = domain.RefundStatusFailed
+		refund.FailureReason = refundFailedReason
+		if updErr := uc.refundRepo.Update(ctx, refund); updErr != nil {
+			return domain.Refund{}, fmt.Errorf("%s: failed to release refund reservation: %w", op, updErr)
+		}
+		return domain.Refund{}, fmt.Errorf("%s: %w: %w", op, domain.ErrPaymentProviderFailed, err)
+	}
+
+	// 3. Фиксация возврата и событие payment_refunded в одной транзакции
+	err = uc.txManager.WithinTx(ctx, func(txCtx context.Context) error {
+		return uc.complete(txCtx, &refund, providerRef)
+	})
+	if err != nil {
+		return domain.Refund{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return refund, nil
+}
+
+// ReconcilePendingRefunds завершает возвраты, зависшие в pending: например, если сервис
+// остановился между запросом к провайдеру и фиксацией результата. Итог берётся у провайдера
+// по ключу идемпотентности возврата. Возврат, о котором провайдер не знает, завершается
+// неудачей — резерв освобождается, и повтор запроса с тем же ключом зарезервирует сумму заново.
+func (uc *RefundUseCase) ReconcilePendingRefunds(ctx context.Context, staleAfter time.Duration, limit int) (int, error) {
+	const op = "refundUseCase.ReconcilePendingRefunds"
+
+	refunds, err := uc.refundRepo.ListStalePending(ctx, staleAfter, limit)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var settled int
+	for _, refund := range refunds {
+		done, err := uc.reconcile(ctx, refund)
+		if err != nil {
+			return settled, fmt.Errorf("%s: refund %d: %w", op, refund.ID, err)
+		}
+		if done {
+			settled++
+		}
+	}
+
+	return settled, nil
+}
+
+// reconcile применяет к возврату итог, известный провайдеру. Возврат, который провайдер
+// ещё проводит, остаётся в pending до следующей сверки.
+func (uc *RefundUseCase) reconcile(ctx context.Context, refund domain.Refund) (bool, error) {
+	// Запрос к провайдеру — вне транзакции, как и при выполнении возврата
+	result, err := uc.provider.GetRefund(ctx, refund.IdempotencyKey)
+	failureReason := refundFailedReason
+	switch {
+	case errors.Is(err, domain.ErrProviderRefundNotFound):
+		result = domain.ProviderRefund{Status: domain.RefundStatusFailed}
+		failureReason = refundLostReason
+	case err != nil:
+		return false, fmt.Errorf("%w: %w", domain.ErrPaymentProviderFailed, err)
+	}
+	if result.Status == domain.RefundStatusPending {
+		return false, nil
+	}
+
+	var done bool
+	err = uc.txManager.WithinTx(ctx, func(txCtx context.Context) error {
+		// Платёж блокируется раньше возврата — в том же порядке, что и при резервировании
+		if _, err := uc.paymentRepo.FindByID(txCtx, refund.PaymentID); err != nil {
+			return err
+		}
+		locked, err := uc.refundRepo.FindByIdempotencyKey(txCtx, refund.IdempotencyKey)
+		if err != nil {
+			return err
+		}
+		// Возврат успели завершить, пока шёл запрос к провайдеру
+		if locked.Status != domain.RefundStatusPending {
+			return nil
+		}
+
+		done = true
+		if result.Status == domain.RefundStatusSucceeded {
+			return uc.complete(txCtx, &locked, result.Ref)
+		}
+
+		locked.Status = domain.RefundStatusFailed
+		locked.FailureReason = failureReason
+		return uc.refundRepo.Update(txCtx, locked)
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return done, nil
+}
+
+// reserve блокирует платёж, проверяет доступный остаток и создаёт pending-возврат.
+// Повтор запроса с ключом успешного возврата возвращает его с replayed = true,
+// повтор после неудачи резервирует ту же сумму заново.
+func (uc *RefundUseCase) reserve(ctx context.Context, cmd domain.RefundCommand) (domain.Payment, domain.Refund, bool, error) {
+	payment, err := uc.findPayment(ctx, cmd)
+	if err != nil {
+		return domain.Payment{}, domain.Refund{}, false, err
+	}
+
+	existing, err := uc.refundRepo.FindByIdempotencyKey(ctx, cmd.IdempotencyKey)
+	switch {
+	case errors.Is(err, domain.ErrRefundNotFound):
+	case err != nil:
+		return domain.Payment{}, domain.Refund{}, false, err
+	case existing.PaymentID != payment.ID || !existing.SameRequest(cmd):
+		return domain.Payment{}, domain.Refund{}, false, domain.ErrRefundIdempotencyMismatch
+	case existing.Status == domain.RefundStatusSucceeded:
+		return payment, existing, true, nil
+	case existing.Status == domain.RefundStatusPending:
+		return domain.Payment{}, domain.Refund{}, false, domain.ErrRefundInProgress
+	}
+
+	if !payment.Status.IsCaptured() {
+		return domain.Payment{}, domain.Refund{}, false, fmt.Errorf("payment status %q: %w", payment.Status, domain.ErrPaymentNotRefundable)
+	}
+
+	pending, err := uc.refundRepo.PendingAmount(ctx, payment.ID)
+	if err != nil {
+		return domain.Payment{}, domain.Refund{}, false, err
+	}
+
+	available := payment.RefundableAmount(pending)
+	if available <= 0 {
+		return domain.Payment{}, domain.Refund{}, false, fmt.Errorf("nothing left to refund: %w", domain.ErrPaymentNotRefundable)
+	}
+
+	amount := domain.RoundAmount(cmd.Amount)
+	if existing.ID != 0 {
+		amount = existing.Amount
+	}
+	if amount <= 0 {
+		amount = available
+	}
+	if amount > available && !domain.AmountsEqual(amount, available) {
+		return domain.Payment{}, domain.Refund{}, false, fmt.Errorf("requested %.2f, available %.2f: %w", amount, available, domain.ErrRefundAmountExceeded)
+	}
+
+	if existing.ID != 0 {
+		existing.Status = domain.RefundStatusPending
+		existing.FailureReason = ""
+		if err := uc.refundRepo.Update(ctx, existing); err != nil {
+			return domain.Payment{}, domain.Refund{}, false, err
+		}
+		return payment, existing, false, nil
+	}
+
+	refund := domain.Refund{
+		PaymentID:      payment.ID,
+		Amount:         amount,
+		Status:         domain.RefundStatusPending,
+		Source:         cmd.Source,
+		Reason:         cmd.Reason,
+		IdempotencyKey: cmd.IdempotencyKey,
+		ReturnUUID:     cmd.ReturnUUID,
+		RequestedBy:    cmd.RequestedBy,
+		CreatedAt:      time.Now().UTC(),
+	}
+	if refund.ID, err = uc.refundRepo.Create(ctx, refund); err != nil {
+		return domain.Payment{}, domain.Refund{}, false, err
+	}
+
+	return payment, refund, false, nil
+}
+
+// complete отмечает возврат выполненным, увеличивает возвращённую сумму платежа
+// и пишет payment_refunded в outbox.
+func (uc *RefundUseCase) complete(ctx context.Context, refund *domain.Refund, providerRef string) error {
+	payment, err := uc.paymentRepo.FindByID(ctx, refund.PaymentID)
+	if err != nil {
+		return err
+	}
+
+	refund.Status = domain.RefundStatusSucceeded
+	refund.ProviderRef = providerRef
+	if err := uc.refundRepo.Update(ctx, *refund); err != nil {
+		return err
+	}
+
+	payment.RefundedAmount = domain.RoundAmount(payment.RefundedAmount + refund.Amount)
+	to := domain.PaymentStatusPartiallyRefunded
+	if payment.IsFullyRefunded() {
+		to = domain.PaymentStatusRefunded
+	}
+	if err := payment.TransitionTo(to); err != nil {
+		return err
+	}
+	if err := uc.paymentRepo.Update(ctx, payment); err != nil {
+		return err
+	}
+
+	return writeOutboxEvent(ctx, uc.outboxWriter, events.TopicPayments, events.EventPaymentRefunded, events.PaymentRefundedPayload{
+		RefundID:      refund.ID,
+		PaymentID:     payment.ID,
+		OrderUUID:     payment.OrderUUID.String(),
+		UserID:        payment.UserID,
+		Amount:        refund.Amount,
+		TotalRefunded: payment.RefundedAmount,
+		FullyRefunded: payment.IsFullyRefunded(),
+		Source:        string(refund.Source),
+		Reason:        refund.Reason,
+		ReturnUUID:    refund.ReturnUUID,
+	})
+}
+
+func (uc *RefundUseCase) findPayment(ctx context.Context, cmd domain.RefundCommand) (domain.Payment, error) {
+	if cmd.PaymentID != 0 {
+		return uc.paymentRepo.FindByID(ctx, cmd.PaymentID)
+	}
+	return uc.paymentRepo.FindByOrderUUID(ctx, cmd.OrderUUID)
+}
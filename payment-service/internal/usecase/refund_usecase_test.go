@@ -0,0 +1,158 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/Wrestler094/scalable-ecommerce-platform/pkg/events"
+	"github.com/Wrestler094/scalable-ecommerce-platform/pkg/outbox"
+
+	"github.com/Wrestler094/scalable-ecommerce-platform/payment-service/internal/domain"
+)
+
+// Фейки реализуют только методы, которые вызывает RefundPayment; остальные методы
+// встроенных интерфейсов не заданы и при вызове паникуют.
+
+type fakePaymentRepo struct {
+	domain.PaymentRepository
+	payment domain.Payment
+}
+
+func (r *fakePaymentRepo) FindByID(_ context.Context, _ int64) (domain.Payment, error) {
+	return r.payment, nil
+}
+
+func (r *fakePaymentRepo) FindByOrderUUID(_ context.Context, _ uuid.UUID) (domain.Payment, error) {
+	return r.payment, nil
+}
+
+func (r *fakePaymentRepo) Update(_ context.Context, payment domain.Payment) error {
+	r.payment = payment
+	return nil
+}
+
+type fakeRefundRepo struct {
+	domain.RefundRepository
+	refunds []domain.Refund
+}
+
+func (r *fakeRefundRepo) Create(_ context.Context, refund domain.Refund) (int64, error) {
+	refund.ID = int64(len(r.refunds) + 1)
+	r.refunds = append(r.refunds, refund)
+	return refund.ID, nil
+}
+
+func (r *fakeRefundRepo) FindByIdempotencyKey(_ context.Context, key string) (domain.Refund, error) {
+	for _, refund := range r.refunds {
+		if refund.IdempotencyKey == key {
+			return refund, nil
+		}
+	}
+	return domain.Refund{}, domain.ErrRefundNotFound
+}
+
+func (r *fakeRefundRepo) PendingAmount(_ context.Context, paymentID int64) (float64, error) {
+	var sum float64
+	for _, refund := range r.refunds {
+		if refund.PaymentID == paymentID && refund.Status == domain.RefundStatusPending {
+			sum += refund.Amount
+		}
+	}
+	return sum, nil
+}
+
+func (r *fakeRefundRepo) Update(_ context.Context, refund domain.Refund) error {
+	r.refunds[refund.ID-1] = refund
+	return nil
+}
+
+type fakeProvider struct {
+	domain.PaymentProvider
+	refundErr error
+	calls     int
+}
+
+func (p *fakeProvider) Refund(_ context.Context, _ string, _ float64, idempotencyKey string) (string, error) {
+	p.calls++
+	if p.refundErr != nil {
+		return "", p.refundErr
+	}
+	return "refund-" + idempotencyKey, nil
+}
+
+type fakeOutboxWriter struct {
+	events []outbox.Event
+}
+
+func (w *fakeOutboxWriter) Write(_ context.Context, evts ...outbox.Event) error {
+	w.events = append(w.events, evts...)
+	return nil
+}
+
+type fakeTxManager struct{}
+
+func (fakeTxManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func TestRefundPaymentProviderFailure(t *testing.T) {
+	payments := &fakePaymentRepo{payment: domain.Payment{
+		ID:          1,
+		OrderUUID:   uuid.MustParse("5f0c7f38-52d6-4b8e-9a3b-8f0a1c2d3e4f"),
+		Amount:      100,
+		Status:      domain.PaymentStatusCaptured,
+		ProviderRef: "pay-1",
+	}}
+	refunds := &fakeRefundRepo{}
+	provider := &fakeProvider{refundErr: errors.New("gateway timeout")}
+	writer := &fakeOutboxWriter{}
+	uc := NewRefundUseCase(payments, refunds, provider, writer, fakeTxManager{})
+
+	cmd := domain.RefundCommand{
+		OrderUUID:      payments.payment.OrderUUID,
+		Amount:         40,
+		Source:         domain.RefundSourceOrderReturn,
+		Reason:         "order return approved",
+		IdempotencyKey: "order_return:r-1",
+		ReturnUUID:     "r-1",
+	}
+
+	_, err := uc.RefundPayment(context.Background(), cmd)
+	if !errors.Is(err, domain.ErrPaymentProviderFailed) {
+		t.Fatalf("RefundPayment() error = %v, want ErrPaymentProviderFailed", err)
+	}
+	if len(refunds.refunds) != 1 || refunds.refunds[0].Status != domain.RefundStatusFailed {
+		t.Fatalf("refunds after provider failure = %+v, want one failed refund", refunds.refunds)
+	}
+	if pending, _ := refunds.PendingAmount(context.Background(), 1); pending != 0 {
+		t.Errorf("pending amount after provider failure = %v, want reservation released", pending)
+	}
+	if payments.payment.RefundedAmount != 0 || len(writer.events) != 0 {
+		t.Errorf("payment refunded %v with %d events, want untouched payment", payments.payment.RefundedAmount, len(writer.events))
+	}
+
+	// Повтор события с тем же ключом заново резервирует ту же сумму и доводит возврат до конца
+	provider.refundErr = nil
+	refund, err := uc.RefundPayment(context.Background(), cmd)
+	if err != nil {
+		t.Fatalf("retried RefundPayment() error = %v", err)
+	}
+	if refund.ID != 1 || refund.Status != domain.RefundStatusSucceeded || refund.Amount != 40 {
+		t.Errorf("retried refund = %+v, want refund 1 succeeded for 40", refund)
+	}
+	if len(refunds.refunds) != 1 {
+		t.Errorf("refunds after retry = %d, want the failed refund reused", len(refunds.refunds))
+	}
+	if provider.calls != 2 {
+		t.Errorf("provider refund calls = %d, want 2", provider.calls)
+	}
+	if payments.payment.RefundedAmount != 40 || payments.payment.Status != domain.PaymentStatusPartiallyRefunded {
+		t.Errorf("payment = %v refunded, status %q, want 40 partially_refunded", payments.payment.RefundedAmount, payments.payment.Status)
+	}
+	if len(writer.events) != 1 || writer.events[0].EventType != events.EventPaymentRefunded {
+		t.Errorf("outbox events = %+v, want a single payment_refunded", writer.events)
+	}
+}
@@ -0,0 +1,6 @@
+-- Топик хранится в каждой записи: одна таблица outbox обслуживает события любых типов и топиков
+ALTER TABLE outbox ADD COLUMN IF NOT EXISTS topic TEXT;
+
+UPDATE outbox SET topic = 'payments' WHERE topic IS NULL;
+
+ALTER TABLE outbox ALTER COLUMN topic SET NOT NULL;
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS outbox_archive;
+
+DROP INDEX IF EXISTS outbox_published_at_idx;
+DROP INDEX IF EXISTS outbox_pending_created_at_idx;
@@ -0,0 +1,16 @@
+-- Поллер выбирает только pending-события: частичный индекс не растёт вместе с опубликованными
+CREATE INDEX IF NOT EXISTS outbox_pending_created_at_idx ON outbox (created_at) WHERE status = 'pending';
+
+-- Очистка выбирает опубликованные события по времени публикации
+CREATE INDEX IF NOT EXISTS outbox_published_at_idx ON outbox (published_at) WHERE status = 'published';
+
+CREATE TABLE IF NOT EXISTS outbox_archive (
+    id UUID PRIMARY KEY,
+    topic TEXT NOT NULL,
+    event_type TEXT NOT NULL,
+    payload JSONB NOT NULL,
+    attempts INT NOT NULL,
+    published_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP,
+    archived_at TIMESTAMP NOT NULL DEFAULT now()
+);
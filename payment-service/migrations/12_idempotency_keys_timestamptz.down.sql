@@ -0,0 +1,3 @@
+ALTER TABLE idempotency_keys
+    ALTER COLUMN created_at TYPE TIMESTAMP,
+    ALTER COLUMN completed_at TYPE TIMESTAMP;
@@ -0,0 +1,6 @@
+-- Проверка давности незавершённого запроса сравнивает created_at со временем сервиса:
+-- TIMESTAMP без зоны давал неверный результат, если TimeZone сессии БД не UTC.
+-- Существующие значения записаны now() и интерпретируются в зоне текущей сессии.
+ALTER TABLE idempotency_keys
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ,
+    ALTER COLUMN completed_at TYPE TIMESTAMPTZ;
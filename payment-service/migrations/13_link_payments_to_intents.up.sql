@@ -0,0 +1,7 @@
+-- Платёж ссылается на намерение оплаты, выданное заказу при оформлении
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS intent_id UUID NULL REFERENCES payment_intents (id);
+
+UPDATE payments p
+SET intent_id = i.id
+FROM payment_intents i
+WHERE i.order_uuid = p.order_uuid AND p.intent_id IS NULL;
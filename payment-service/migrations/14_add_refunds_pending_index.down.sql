@@ -0,0 +1 @@
+DROP INDEX IF EXISTS refunds_pending_updated_at_idx;
@@ -0,0 +1,2 @@
+-- Сверка зависших возвратов выбирает pending-возвраты по времени последнего изменения
+CREATE INDEX IF NOT EXISTS refunds_pending_updated_at_idx ON refunds (updated_at) WHERE status = 'pending';
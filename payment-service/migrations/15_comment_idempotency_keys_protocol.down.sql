@@ -0,0 +1 @@
+COMMENT ON TABLE idempotency_keys IS NULL;
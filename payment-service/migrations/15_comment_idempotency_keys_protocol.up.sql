@@ -0,0 +1,5 @@
+-- Комментарий в 8_create_idempotency_keys устарел: ключ занимается отдельно от транзакции платежа,
+-- до регистрации намерения у провайдера, чтобы параллельные повторы не создавали лишних намерений.
+-- Незавершённую запись освобождает запрос, завершившийся повторяемой ошибкой, а брошенную
+-- (сервис остановился до записи результата) через минуту перехватывает повтор с тем же хешем запроса.
+COMMENT ON TABLE idempotency_keys IS 'ключи идемпотентности оплаты: занимаются до обращения к провайдеру, освобождаются при повторяемой ошибке, незавершённый ключ с тем же хешем запроса перехватывается после таймаута';
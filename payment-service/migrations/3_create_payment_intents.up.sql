@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS payment_intents (
+    id UUID PRIMARY KEY,
+    order_uuid UUID NOT NULL,
+    user_id BIGINT NOT NULL,
+    amount NUMERIC(10, 2) NOT NULL,
+    checkout_url TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    UNIQUE (order_uuid)
+);
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS payments_order_uuid_key;
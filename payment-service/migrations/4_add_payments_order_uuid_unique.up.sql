@@ -0,0 +1,3 @@
+-- Один заказ оплачивается один раз: индекс защищает от параллельных платежей,
+-- прошедших сверку с order-service одновременно
+CREATE UNIQUE INDEX IF NOT EXISTS payments_order_uuid_key ON payments (order_uuid);
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS payments_provider_ref_key;
+
+ALTER TABLE payments
+    DROP COLUMN IF EXISTS updated_at,
+    DROP COLUMN IF EXISTS provider_ref,
+    DROP COLUMN IF EXISTS provider,
+    DROP COLUMN IF EXISTS status;
@@ -0,0 +1,11 @@
+-- Итог платежа теперь приходит от провайдера вебхуком. Платежи, записанные
+-- до появления провайдеров, сразу считались успешными.
+ALTER TABLE payments
+    ADD COLUMN IF NOT EXISTS status TEXT NOT NULL DEFAULT 'captured',
+    ADD COLUMN IF NOT EXISTS provider TEXT,
+    ADD COLUMN IF NOT EXISTS provider_ref TEXT,
+    ADD COLUMN IF NOT EXISTS updated_at TIMESTAMP NOT NULL DEFAULT now();
+
+ALTER TABLE payments ALTER COLUMN status DROP DEFAULT;
+
+CREATE UNIQUE INDEX IF NOT EXISTS payments_provider_ref_key ON payments (provider_ref);
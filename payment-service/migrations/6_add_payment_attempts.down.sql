@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS payment_attempts;
+
+ALTER TABLE payments DROP CONSTRAINT IF EXISTS payments_status_check;
+
+ALTER TABLE payments DROP COLUMN IF EXISTS failure_reason;
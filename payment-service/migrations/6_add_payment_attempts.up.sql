@@ -0,0 +1,29 @@
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS failure_reason TEXT;
+
+ALTER TABLE payments
+    ADD CONSTRAINT payments_status_check
+    CHECK (status IN ('created', 'pending', 'authorized', 'captured', 'failed', 'refunded', 'partially_refunded'));
+
+-- Каждое обращение к провайдеру по платежу — отдельная попытка. Платёж хранит
+-- текущее состояние, попытки — историю, включая неудачные.
+CREATE TABLE IF NOT EXISTS payment_attempts (
+    id BIGSERIAL PRIMARY KEY,
+    payment_id INT NOT NULL REFERENCES payments (id) ON DELETE CASCADE,
+    provider TEXT NOT NULL,
+    provider_ref TEXT NOT NULL,
+    amount NUMERIC(10, 2) NOT NULL,
+    status TEXT NOT NULL,
+    failure_reason TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS payment_attempts_provider_ref_key ON payment_attempts (provider_ref);
+CREATE INDEX IF NOT EXISTS payment_attempts_payment_id_idx ON payment_attempts (payment_id);
+
+-- Платежи, созданные через провайдера, получают попытку с их текущим состоянием
+INSERT INTO payment_attempts (payment_id, provider, provider_ref, amount, status, created_at, updated_at)
+SELECT id, provider, provider_ref, amount, status, created_at, updated_at
+FROM payments
+WHERE provider_ref IS NOT NULL
+ON CONFLICT DO NOTHING;
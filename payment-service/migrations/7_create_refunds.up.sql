@@ -0,0 +1,26 @@
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS refunded_amount NUMERIC(10, 2) NOT NULL DEFAULT 0;
+
+-- Последний рубеж против возврата сверх списанного: даже при ошибке в коде
+-- сумма выполненных возвратов не превысит сумму платежа
+ALTER TABLE payments
+    ADD CONSTRAINT payments_refunded_amount_check
+    CHECK (refunded_amount >= 0 AND refunded_amount <= amount);
+
+CREATE TABLE IF NOT EXISTS refunds (
+    id BIGSERIAL PRIMARY KEY,
+    payment_id INT NOT NULL REFERENCES payments (id) ON DELETE CASCADE,
+    amount NUMERIC(10, 2) NOT NULL CHECK (amount > 0),
+    status TEXT NOT NULL,
+    source TEXT NOT NULL,
+    reason TEXT NOT NULL,
+    idempotency_key TEXT NOT NULL,
+    return_uuid UUID,
+    requested_by BIGINT,
+    provider_ref TEXT,
+    failure_reason TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS refunds_idempotency_key_key ON refunds (idempotency_key);
+CREATE INDEX IF NOT EXISTS refunds_payment_id_idx ON refunds (payment_id);
@@ -0,0 +1,12 @@
+-- Ключи идемпотентности оплаты. Ключ резервируется в одной транзакции с платежом,
+-- поэтому два параллельных запроса с одним ключом не могут оба создать попытку оплаты.
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    user_id BIGINT NOT NULL,
+    key TEXT NOT NULL,
+    request_hash TEXT NOT NULL, -- хеш тела исходного запроса
+    order_uuid UUID NOT NULL,
+    response JSONB NULL, -- ответ исходного запроса
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    completed_at TIMESTAMP NULL, -- NULL, пока исходный запрос не завершён
+    PRIMARY KEY (user_id, key)
+);
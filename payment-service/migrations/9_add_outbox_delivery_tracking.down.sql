@@ -0,0 +1,7 @@
+ALTER TABLE outbox DROP CONSTRAINT IF EXISTS outbox_status_check;
+
+ALTER TABLE outbox
+    DROP COLUMN IF EXISTS last_error,
+    DROP COLUMN IF EXISTS next_attempt_at,
+    DROP COLUMN IF EXISTS attempts,
+    DROP COLUMN IF EXISTS status;
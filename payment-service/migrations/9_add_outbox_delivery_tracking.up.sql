@@ -0,0 +1,13 @@
+-- Доставка событий outbox: pending -> published, либо failed после исчерпания попыток.
+-- next_attempt_at служит и расписанием повторов, и арендой: захваченное поллером событие
+-- не выдаётся другим репликам, пока аренда не истечёт.
+ALTER TABLE outbox
+    ADD COLUMN IF NOT EXISTS status TEXT NOT NULL DEFAULT 'pending',
+    ADD COLUMN IF NOT EXISTS attempts INT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS next_attempt_at TIMESTAMP NOT NULL DEFAULT now(),
+    ADD COLUMN IF NOT EXISTS last_error TEXT;
+
+UPDATE outbox SET status = 'published' WHERE published_at IS NOT NULL;
+
+ALTER TABLE outbox
+    ADD CONSTRAINT outbox_status_check CHECK (status IN ('pending', 'published', 'failed'));
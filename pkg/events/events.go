@@ -3,4 +3,13 @@ package events
 const (
 	EventPaymentSuccessful = "payment_successful"
 	EventPaymentFailed     = "payment_failed"
+	EventPaymentRefunded   = "payment_refunded"
+
+	EventOrderCreated       = "order_created"
+	EventOrderPaid          = "order_paid"
+	EventOrderCancelled     = "order_cancelled"
+	EventOrderStatusChanged = "order_status_changed"
+	EventOrderExpired       = "order_expired"
+
+	EventOrderReturnApproved = "order_return_approved"
 )
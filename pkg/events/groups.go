@@ -3,4 +3,5 @@ package events
 const (
 	NotificationGroup = "notification-service"
 	OrderGroup        = "order-service"
+	PaymentGroup      = "payment-service"
 )
@@ -5,3 +5,98 @@ type PaymentSuccessfulPayload struct {
 	UserID    int64   `json:"user_id"`
 	Amount    float64 `json:"amount"`
 }
+
+// PaymentFailedPayload публикуется, если платёж по заказу не прошёл.
+type PaymentFailedPayload struct {
+	OrderUUID string  `json:"order_uuid"`
+	UserID    int64   `json:"user_id"`
+	Amount    float64 `json:"amount"`
+	Reason    string  `json:"reason"`
+}
+
+// PaymentRefundedPayload публикуется после каждого успешного возврата.
+// Amount — сумма этого возврата, TotalRefunded — всё, что возвращено по платежу.
+// ReturnUUID заполняется, если возврат выполнен по одобренной заявке order_return_approved.
+type PaymentRefundedPayload struct {
+	RefundID      int64   `json:"refund_id"`
+	PaymentID     int64   `json:"payment_id"`
+	OrderUUID     string  `json:"order_uuid"`
+	UserID        int64   `json:"user_id"`
+	Amount        float64 `json:"amount"`
+	TotalRefunded float64 `json:"total_refunded"`
+	FullyRefunded bool    `json:"fully_refunded"`
+	Source        string  `json:"source"`
+	Reason        string  `json:"reason"`
+	ReturnUUID    string  `json:"return_uuid,omitempty"`
+}
+
+// OrderItemPayload — позиция заказа в событиях order-service.
+type OrderItemPayload struct {
+	ProductID int64   `json:"product_id"`
+	Quantity  int     `json:"quantity"`
+	Price     float64 `json:"price"`
+}
+
+// OrderCreatedPayload публикуется после создания заказа.
+type OrderCreatedPayload struct {
+	OrderUUID string             `json:"order_uuid"`
+	UserID    int64              `json:"user_id"`
+	Amount    float64            `json:"amount"`
+	Items     []OrderItemPayload `json:"items"`
+}
+
+// OrderPaidPayload публикуется, когда заказ переходит в статус paid.
+type OrderPaidPayload struct {
+	OrderUUID string  `json:"order_uuid"`
+	UserID    int64   `json:"user_id"`
+	Amount    float64 `json:"amount"`
+}
+
+// OrderCancelledPayload публикуется при отмене заказа.
+// RefundRequired выставляется, если заказ был оплачен и платёж нужно вернуть.
+type OrderCancelledPayload struct {
+	OrderUUID      string  `json:"order_uuid"`
+	UserID         int64   `json:"user_id"`
+	Amount         float64 `json:"amount"`
+	Reason         string  `json:"reason"`
+	CancelledBy    string  `json:"cancelled_by"`
+	RefundRequired bool    `json:"refund_required"`
+}
+
+// OrderStatusChangedPayload публикуется при ручной смене статуса заказа администратором.
+type OrderStatusChangedPayload struct {
+	OrderUUID  string `json:"order_uuid"`
+	UserID     int64  `json:"user_id"`
+	FromStatus string `json:"from_status"`
+	ToStatus   string `json:"to_status"`
+	Comment    string `json:"comment"`
+	ChangedBy  int64  `json:"changed_by"`
+}
+
+// OrderExpiredPayload публикуется, когда неоплаченный заказ отменяется по истечении срока оплаты.
+// Items передаются, чтобы потребители могли снять резервы по позициям заказа.
+type OrderExpiredPayload struct {
+	OrderUUID string             `json:"order_uuid"`
+	UserID    int64              `json:"user_id"`
+	Amount    float64            `json:"amount"`
+	Items     []OrderItemPayload `json:"items"`
+}
+
+// OrderReturnItemPayload — возвращаемая позиция заказа. Amount — сумма к возврату по позиции.
+type OrderReturnItemPayload struct {
+	ProductID int64   `json:"product_id"`
+	Quantity  int     `json:"quantity"`
+	Amount    float64 `json:"amount"`
+}
+
+// OrderReturnApprovedPayload публикуется после одобрения возврата: payment-service возвращает Amount.
+// FullyRefunded выставляется, если после возврата заказ перешёл в статус refunded:
+// тогда Amount включает и стоимость доставки.
+type OrderReturnApprovedPayload struct {
+	ReturnUUID    string                   `json:"return_uuid"`
+	OrderUUID     string                   `json:"order_uuid"`
+	UserID        int64                    `json:"user_id"`
+	Amount        float64                  `json:"amount"`
+	Items         []OrderReturnItemPayload `json:"items"`
+	FullyRefunded bool                     `json:"fully_refunded"`
+}
@@ -2,4 +2,5 @@ package events
 
 const (
 	TopicPayments = "payments"
+	TopicOrders   = "orders"
 )
@@ -0,0 +1,118 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Wrestler094/scalable-ecommerce-platform/pkg/logger"
+)
+
+// RetentionMode — что делать с опубликованными событиями после истечения срока хранения.
+type RetentionMode string
+
+const (
+	RetentionDelete  RetentionMode = "delete"
+	RetentionArchive RetentionMode = "archive"
+)
+
+// IsValid сообщает, является ли значение известным режимом хранения.
+func (m RetentionMode) IsValid() bool {
+	return m == RetentionDelete || m == RetentionArchive
+}
+
+// CleanerConfig — параметры очистки outbox.
+type CleanerConfig struct {
+	Interval time.Duration
+	// Retention — сколько хранятся опубликованные события
+	Retention time.Duration
+	// BatchSize — сколько событий удаляется одним запросом, чтобы не держать долгие блокировки
+	BatchSize int
+	Mode      RetentionMode
+	// MetricsNamespace — префикс метрик, обычно имя сервиса: <namespace>_outbox_*
+	MetricsNamespace string
+}
+
+// Cleaner периодически удаляет или архивирует опубликованные события старше Retention.
+// Необработанные события и события в статусе failed не трогает.
+type Cleaner struct {
+	pruner  Pruner
+	logger  logger.Logger
+	cfg     CleanerConfig
+	metrics *cleanerMetrics
+}
+
+// NewCleaner создаёт Cleaner. Метрики регистрируются в реестре Prometheus по умолчанию,
+// поэтому в процессе может быть только один Cleaner на MetricsNamespace.
+func NewCleaner(pruner Pruner, logger logger.Logger, cfg CleanerConfig) (*Cleaner, error) {
+	const op = "outbox.NewCleaner"
+
+	if !cfg.Mode.IsValid() {
+		return nil, fmt.Errorf("%s: unknown retention mode %q", op, cfg.Mode)
+	}
+	if cfg.BatchSize <= 0 {
+		return nil, fmt.Errorf("%s: batch size must be positive, got %d", op, cfg.BatchSize)
+	}
+
+	return &Cleaner{
+		pruner:  pruner,
+		logger:  logger,
+		cfg:     cfg,
+		metrics: newCleanerMetrics(cfg.MetricsNamespace),
+	}, nil
+}
+
+// Run чистит outbox, пока не отменён ctx.
+func (c *Cleaner) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.clean(ctx)
+		}
+	}
+}
+
+// clean удаляет истёкшие события пачками, пока очередная пачка не окажется неполной.
+func (c *Cleaner) clean(ctx context.Context) {
+	const op = "outbox.Cleaner.clean"
+
+	start := time.Now()
+	defer func() {
+		c.metrics.duration.Observe(time.Since(start).Seconds())
+	}()
+
+	total := 0
+	for ctx.Err() == nil {
+		n, err := c.prune(ctx)
+		if err != nil {
+			c.metrics.errors.Inc()
+			c.logger.WithOp(op).WithError(err).Error("failed to clean outbox", "mode", c.cfg.Mode)
+			return
+		}
+
+		total += n
+		c.metrics.removed.WithLabelValues(string(c.cfg.Mode)).Add(float64(n))
+
+		if n < c.cfg.BatchSize {
+			break
+		}
+	}
+
+	c.metrics.lastSuccess.SetToCurrentTime()
+
+	if total > 0 {
+		c.logger.WithOp(op).Info("outbox cleaned", "mode", c.cfg.Mode, "count", total)
+	}
+}
+
+func (c *Cleaner) prune(ctx context.Context) (int, error) {
+	if c.cfg.Mode == RetentionArchive {
+		return c.pruner.ArchivePublished(ctx, c.cfg.Retention, c.cfg.BatchSize)
+	}
+	return c.pruner.DeletePublished(ctx, c.cfg.Retention, c.cfg.BatchSize)
+}
@@ -0,0 +1,103 @@
+package outbox
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Wrestler094/scalable-ecommerce-platform/pkg/logger"
+)
+
+func TestNewCleanerValidatesConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  CleanerConfig
+	}{
+		{"unknown mode", CleanerConfig{Mode: "truncate", BatchSize: 100}},
+		{"empty mode", CleanerConfig{BatchSize: 100}},
+		{"zero batch", CleanerConfig{Mode: RetentionDelete}},
+		{"negative batch", CleanerConfig{Mode: RetentionArchive, BatchSize: -1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewCleaner(&fakePruner{}, nil, tt.cfg); err == nil {
+				t.Errorf("NewCleaner(%+v) error = nil, want error", tt.cfg)
+			}
+		})
+	}
+}
+
+// fakePruner отдаёт заданные размеры пачек и запоминает, каким способом его вызвали.
+type fakePruner struct {
+	batches  []int
+	deleted  int
+	archived int
+}
+
+func (f *fakePruner) next() int {
+	if len(f.batches) == 0 {
+		return 0
+	}
+	n := f.batches[0]
+	f.batches = f.batches[1:]
+	return n
+}
+
+func (f *fakePruner) DeletePublished(context.Context, time.Duration, int) (int, error) {
+	n := f.next()
+	f.deleted += n
+	return n, nil
+}
+
+func (f *fakePruner) ArchivePublished(context.Context, time.Duration, int) (int, error) {
+	n := f.next()
+	f.archived += n
+	return n, nil
+}
+
+func TestCleanerClean(t *testing.T) {
+	log, err := logger.NewLogger("error")
+	if err != nil {
+		t.Fatalf("NewLogger: %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		mode         RetentionMode
+		batches      []int
+		wantDeleted  int
+		wantArchived int
+		wantLeft     int
+	}{
+		{"delete until partial batch", RetentionDelete, []int{10, 10, 3, 10}, 23, 0, 1},
+		{"archive until empty batch", RetentionArchive, []int{10, 0, 10}, 0, 10, 1},
+		{"nothing to clean", RetentionDelete, nil, 0, 0, 0},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pruner := &fakePruner{batches: tt.batches}
+			c, err := NewCleaner(pruner, log, CleanerConfig{
+				Retention: time.Hour,
+				BatchSize: 10,
+				Mode:      tt.mode,
+
+				// Метрики регистрируются глобально: у каждого Cleaner своё пространство имён
+				MetricsNamespace: "cleaner_test_" + string(rune('a'+i)),
+			})
+			if err != nil {
+				t.Fatalf("NewCleaner: %v", err)
+			}
+
+			c.clean(context.Background())
+
+			if pruner.deleted != tt.wantDeleted || pruner.archived != tt.wantArchived {
+				t.Errorf("deleted, archived = %d, %d, want %d, %d", pruner.deleted, pruner.archived, tt.wantDeleted, tt.wantArchived)
+			}
+			if len(pruner.batches) != tt.wantLeft {
+				t.Errorf("batches left = %d, want %d", len(pruner.batches), tt.wantLeft)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+
+	"github.com/Wrestler094/scalable-ecommerce-platform/pkg/events"
+)
+
+var _ Publisher = (*KafkaPublisher)(nil)
+
+// KafkaPublisher публикует события в топик из Event.Topic, оборачивая payload в events.Envelope.
+// Ключ сообщения — ID события.
+type KafkaPublisher struct {
+	writer *kafka.Writer
+}
+
+func NewKafkaPublisher(brokerAddresses []string) *KafkaPublisher {
+	writer := &kafka.Writer{
+		Addr:     kafka.TCP(brokerAddresses...),
+		Balancer: &kafka.LeastBytes{},
+	}
+
+	return &KafkaPublisher{writer: writer}
+}
+
+func (p *KafkaPublisher) Publish(ctx context.Context, evts []Event) []error {
+	const op = "outbox.KafkaPublisher.Publish"
+
+	errs := make([]error, len(evts))
+	msgs := make([]kafka.Message, 0, len(evts))
+	// idx сопоставляет сообщение батча с событием: события, которые не удалось сериализовать, в батч не попадают
+	idx := make([]int, 0, len(evts))
+
+	for i, evt := range evts {
+		msg, err := newMessage(evt)
+		if err != nil {
+			errs[i] = fmt.Errorf("%s: %w", op, err)
+			continue
+		}
+		msgs = append(msgs, msg)
+		idx = append(idx, i)
+	}
+
+	if len(msgs) == 0 {
+		return errs
+	}
+
+	err := p.writer.WriteMessages(ctx, msgs...)
+	if err == nil {
+		return errs
+	}
+
+	// При частичной неудаче kafka-go возвращает ошибку для каждого сообщения батча
+	var writeErrs kafka.WriteErrors
+	if errors.As(err, &writeErrs) && len(writeErrs) == len(msgs) {
+		for j, writeErr := range writeErrs {
+			if writeErr != nil {
+				errs[idx[j]] = fmt.Errorf("%s: %w", op, writeErr)
+			}
+		}
+		return errs
+	}
+
+	for _, i := range idx {
+		errs[i] = fmt.Errorf("%s: %w", op, err)
+	}
+	return errs
+}
+
+func (p *KafkaPublisher) Close() error {
+	return p.writer.Close()
+}
+
+func newMessage(evt Event) (kafka.Message, error) {
+	envelope := events.Envelope[json.RawMessage]{
+		EventID:   evt.ID,
+		EventType: evt.EventType,
+		Timestamp: evt.CreatedAt.Format(time.RFC3339),
+		Payload:   evt.Payload,
+	}
+
+	data, err := json.Marshal(envelope)
+	if err != nil {
+		return kafka.Message{}, fmt.Errorf("failed to marshal envelope: %w", err)
+	}
+
+	return kafka.Message{
+		Key:   []byte(evt.ID.String()),
+		Value: data,
+		Topic: evt.Topic,
+	}, nil
+}
@@ -0,0 +1,91 @@
+package outbox
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const _metricsSubsystem = "outbox"
+
+// relayMetrics — метрики Relay. Глубина и задержка очереди берутся из БД, поэтому
+// на всех репликах они одинаковые, а счётчики публикаций у каждой реплики свои.
+type relayMetrics struct {
+	pending       prometheus.Gauge
+	failed        prometheus.Gauge
+	lag           prometheus.Gauge
+	published     prometheus.Counter
+	publishErrors *prometheus.CounterVec
+}
+
+func newRelayMetrics(namespace string) *relayMetrics {
+	return &relayMetrics{
+		pending: promauto.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: _metricsSubsystem,
+			Name:      "pending_events",
+			Help:      "Number of outbox events waiting to be published.",
+		}),
+		failed: promauto.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: _metricsSubsystem,
+			Name:      "failed_events",
+			Help:      "Number of outbox events that exhausted their publish attempts.",
+		}),
+		lag: promauto.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: _metricsSubsystem,
+			Name:      "lag_seconds",
+			Help:      "Age of the oldest unpublished outbox event.",
+		}),
+		published: promauto.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: _metricsSubsystem,
+			Name:      "published_total",
+			Help:      "Outbox events published to the broker.",
+		}),
+		publishErrors: promauto.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: _metricsSubsystem,
+			Name:      "publish_errors_total",
+			Help:      "Failed outbox publish attempts by outcome: retry or failed.",
+		}, []string{"outcome"}),
+	}
+}
+
+// cleanerMetrics — метрики Cleaner.
+type cleanerMetrics struct {
+	removed     *prometheus.CounterVec
+	errors      prometheus.Counter
+	duration    prometheus.Histogram
+	lastSuccess prometheus.Gauge
+}
+
+func newCleanerMetrics(namespace string) *cleanerMetrics {
+	return &cleanerMetrics{
+		removed: promauto.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: _metricsSubsystem,
+			Name:      "cleaned_total",
+			Help:      "Published outbox events removed by retention, by mode: delete or archive.",
+		}, []string{"mode"}),
+		errors: promauto.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: _metricsSubsystem,
+			Name:      "cleanup_errors_total",
+			Help:      "Failed outbox cleanup runs.",
+		}),
+		duration: promauto.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: _metricsSubsystem,
+			Name:      "cleanup_duration_seconds",
+			Help:      "Duration of outbox cleanup runs.",
+			Buckets:   prometheus.DefBuckets,
+		}),
+		lastSuccess: promauto.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: _metricsSubsystem,
+			Name:      "last_cleanup_timestamp_seconds",
+			Help:      "Unix time of the last successful outbox cleanup run.",
+		}),
+	}
+}
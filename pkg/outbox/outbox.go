@@ -0,0 +1,107 @@
+// Package outbox реализует transactional outbox: события пишутся в таблицу outbox
+// в той же транзакции, что и бизнес-данные, а Relay публикует их в брокер.
+//
+// Ожидаемая схема таблицы (имя меняется опцией Table):
+//
+//	CREATE TABLE outbox (
+//	    id UUID PRIMARY KEY,
+//	    topic TEXT NOT NULL,
+//	    event_type TEXT NOT NULL,
+//	    payload JSONB NOT NULL,
+//	    status TEXT NOT NULL DEFAULT 'pending',
+//	    attempts INT NOT NULL DEFAULT 0,
+//	    next_attempt_at TIMESTAMP NOT NULL DEFAULT now(),
+//	    last_error TEXT,
+//	    published_at TIMESTAMP NULL,
+//	    created_at TIMESTAMP DEFAULT now()
+//	);
+//
+// Для режима RetentionArchive нужна архивная таблица (имя меняется опцией ArchiveTable):
+//
+//	CREATE TABLE outbox_archive (
+//	    id UUID PRIMARY KEY,
+//	    topic TEXT NOT NULL,
+//	    event_type TEXT NOT NULL,
+//	    payload JSONB NOT NULL,
+//	    attempts INT NOT NULL,
+//	    published_at TIMESTAMP NOT NULL,
+//	    created_at TIMESTAMP,
+//	    archived_at TIMESTAMP NOT NULL DEFAULT now()
+//	);
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Event — событие outbox. Payload хранится как сырой JSON, поэтому одна таблица
+// вмещает события любых типов и топиков.
+type Event struct {
+	ID        uuid.UUID
+	Topic     string
+	EventType string
+	Payload   json.RawMessage
+	CreatedAt time.Time
+	// Attempts — число неудачных попыток публикации
+	Attempts int
+}
+
+// NewEvent сериализует payload и собирает событие для записи в outbox.
+func NewEvent(topic, eventType string, payload any) (Event, error) {
+	const op = "outbox.NewEvent"
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return Event{}, fmt.Errorf("%s: failed to marshal %s payload: %w", op, eventType, err)
+	}
+
+	return Event{
+		ID:        uuid.New(),
+		Topic:     topic,
+		EventType: eventType,
+		Payload:   data,
+		CreatedAt: time.Now().UTC(),
+	}, nil
+}
+
+// Writer записывает события в outbox. Если в ctx есть транзакция, запись идёт в ней.
+type Writer interface {
+	Write(ctx context.Context, evts ...Event) error
+}
+
+// Stats — состояние очереди outbox для метрик.
+type Stats struct {
+	Pending int
+	Failed  int
+	// Lag — возраст самого старого неопубликованного события, 0 при пустой очереди
+	Lag time.Duration
+}
+
+// Reader выдаёт события Relay. Захваченные события арендуются на lease
+// и не выдаются другим репликам, пока Relay не отметит результат или аренда не истечёт.
+type Reader interface {
+	ClaimPending(ctx context.Context, limit int, lease time.Duration) ([]Event, error)
+	MarkPublished(ctx context.Context, ids []uuid.UUID) error
+	// ScheduleRetry откладывает следующую попытку публикации на delay.
+	ScheduleRetry(ctx context.Context, id uuid.UUID, delay time.Duration, lastErr string) error
+	// MarkFailed переводит событие в терминальный статус failed: Relay его больше не выдаёт.
+	MarkFailed(ctx context.Context, id uuid.UUID, lastErr string) error
+	Stats(ctx context.Context) (Stats, error)
+}
+
+// Publisher доставляет события в брокер. Возвращает ошибку для каждого события
+// в порядке evts, nil — событие опубликовано.
+type Publisher interface {
+	Publish(ctx context.Context, evts []Event) []error
+}
+
+// Pruner удаляет или архивирует опубликованные события.
+type Pruner interface {
+	DeletePublished(ctx context.Context, olderThan time.Duration, limit int) (int, error)
+	ArchivePublished(ctx context.Context, olderThan time.Duration, limit int) (int, error)
+}
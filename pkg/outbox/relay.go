@@ -0,0 +1,156 @@
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Wrestler094/scalable-ecommerce-platform/pkg/logger"
+)
+
+// RelayConfig — параметры публикации событий из outbox.
+type RelayConfig struct {
+	Interval  time.Duration
+	BatchSize int
+	// MaxAttempts — после стольких неудачных попыток событие переводится в failed
+	MaxAttempts int
+	// BaseBackoff и MaxBackoff задают экспоненциальную задержку между попытками
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+	// Lease — на сколько захваченные события скрываются от других реплик
+	Lease time.Duration
+	// MetricsNamespace — префикс метрик, обычно имя сервиса: <namespace>_outbox_*
+	MetricsNamespace string
+}
+
+// Relay периодически захватывает события из Reader и передаёт их Publisher.
+// Безопасен для запуска на нескольких репликах сервиса одновременно.
+type Relay struct {
+	reader    Reader
+	publisher Publisher
+	logger    logger.Logger
+	cfg       RelayConfig
+	metrics   *relayMetrics
+}
+
+// NewRelay создаёт Relay. Метрики регистрируются в реестре Prometheus по умолчанию,
+// поэтому в процессе может быть только один Relay на MetricsNamespace.
+func NewRelay(reader Reader, publisher Publisher, logger logger.Logger, cfg RelayConfig) *Relay {
+	return &Relay{
+		reader:    reader,
+		publisher: publisher,
+		logger:    logger,
+		cfg:       cfg,
+		metrics:   newRelayMetrics(cfg.MetricsNamespace),
+	}
+}
+
+// Run публикует события, пока не отменён ctx.
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.process(ctx)
+			r.observe(ctx)
+		}
+	}
+}
+
+func (r *Relay) process(ctx context.Context) {
+	const op = "outbox.Relay.process"
+
+	evts, err := r.reader.ClaimPending(ctx, r.cfg.BatchSize, r.cfg.Lease)
+	if err != nil {
+		r.logger.WithOp(op).WithError(err).Error("failed to claim events")
+		return
+	}
+
+	if len(evts) == 0 {
+		return
+	}
+
+	errs := r.publisher.Publish(ctx, evts)
+
+	published := make([]uuid.UUID, 0, len(evts))
+	for i, evt := range evts {
+		if errs[i] == nil {
+			published = append(published, evt.ID)
+			continue
+		}
+
+		r.handleFailure(ctx, evt, errs[i])
+	}
+
+	if len(published) == 0 {
+		return
+	}
+
+	// Если отметка не удалась, события будут опубликованы повторно после истечения аренды:
+	// консьюмеры дедуплицируют их по ID события
+	if err := r.reader.MarkPublished(ctx, published); err != nil {
+		r.logger.WithOp(op).WithError(err).Error("failed to mark published", "count", len(published))
+		return
+	}
+
+	r.metrics.published.Add(float64(len(published)))
+}
+
+// handleFailure откладывает следующую попытку публикации или, если попытки исчерпаны,
+// переводит событие в failed.
+func (r *Relay) handleFailure(ctx context.Context, evt Event, publishErr error) {
+	const op = "outbox.Relay.handleFailure"
+
+	log := r.logger.WithOp(op).With("event", evt.ID, "event_type", evt.EventType, "attempt", evt.Attempts+1)
+
+	if evt.Attempts+1 >= r.cfg.MaxAttempts {
+		r.metrics.publishErrors.WithLabelValues("failed").Inc()
+		log.WithError(publishErr).Error("event publish attempts exhausted")
+
+		if err := r.reader.MarkFailed(ctx, evt.ID, publishErr.Error()); err != nil {
+			log.WithError(err).Error("failed to mark event failed")
+		}
+		return
+	}
+
+	r.metrics.publishErrors.WithLabelValues("retry").Inc()
+	log.WithError(publishErr).Warn("failed to publish event, will retry")
+
+	if err := r.reader.ScheduleRetry(ctx, evt.ID, r.backoff(evt.Attempts), publishErr.Error()); err != nil {
+		log.WithError(err).Error("failed to schedule retry")
+	}
+}
+
+// backoff возвращает задержку перед попыткой с номером attempts+1: BaseBackoff * 2^attempts,
+// но не больше MaxBackoff.
+func (r *Relay) backoff(attempts int) time.Duration {
+	delay := r.cfg.BaseBackoff
+	for range attempts {
+		delay *= 2
+		if delay >= r.cfg.MaxBackoff {
+			return r.cfg.MaxBackoff
+		}
+	}
+
+	return min(delay, r.cfg.MaxBackoff)
+}
+
+// observe обновляет метрики глубины и задержки очереди.
+func (r *Relay) observe(ctx context.Context) {
+	const op = "outbox.Relay.observe"
+
+	stats, err := r.reader.Stats(ctx)
+	if err != nil {
+		r.logger.WithOp(op).WithError(err).Warn("failed to collect outbox stats")
+		return
+	}
+
+	r.metrics.pending.Set(float64(stats.Pending))
+	r.metrics.failed.Set(float64(stats.Failed))
+	r.metrics.lag.Set(stats.Lag.Seconds())
+}
@@ -0,0 +1,142 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Wrestler094/scalable-ecommerce-platform/pkg/logger"
+)
+
+func TestRelayBackoff(t *testing.T) {
+	r := &Relay{cfg: RelayConfig{BaseBackoff: time.Second, MaxBackoff: time.Minute}}
+
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{0, time.Second},
+		{1, 2 * time.Second},
+		{2, 4 * time.Second},
+		{5, 32 * time.Second},
+		{6, time.Minute},
+		{7, time.Minute},
+		{100, time.Minute},
+	}
+
+	for _, tt := range tests {
+		if got := r.backoff(tt.attempts); got != tt.want {
+			t.Errorf("backoff(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
+
+func TestRelayBackoffBaseAboveMax(t *testing.T) {
+	r := &Relay{cfg: RelayConfig{BaseBackoff: time.Hour, MaxBackoff: time.Minute}}
+
+	if got := r.backoff(0); got != time.Minute {
+		t.Errorf("backoff(0) = %v, want %v", got, time.Minute)
+	}
+}
+
+// fakeReader запоминает, как Relay распорядился событиями.
+type fakeReader struct {
+	events    []Event
+	published []uuid.UUID
+	retries   map[uuid.UUID]time.Duration
+	failed    []uuid.UUID
+}
+
+func (f *fakeReader) ClaimPending(context.Context, int, time.Duration) ([]Event, error) {
+	return f.events, nil
+}
+
+func (f *fakeReader) MarkPublished(_ context.Context, ids []uuid.UUID) error {
+	f.published = append(f.published, ids...)
+	return nil
+}
+
+func (f *fakeReader) ScheduleRetry(_ context.Context, id uuid.UUID, delay time.Duration, _ string) error {
+	f.retries[id] = delay
+	return nil
+}
+
+func (f *fakeReader) MarkFailed(_ context.Context, id uuid.UUID, _ string) error {
+	f.failed = append(f.failed, id)
+	return nil
+}
+
+func (f *fakeReader) Stats(context.Context) (Stats, error) {
+	return Stats{}, nil
+}
+
+// fakePublisher публикует все события, кроме перечисленных в fail.
+type fakePublisher struct {
+	fail map[uuid.UUID]bool
+}
+
+func (f *fakePublisher) Publish(_ context.Context, evts []Event) []error {
+	errs := make([]error, len(evts))
+	for i, evt := range evts {
+		if f.fail[evt.ID] {
+			errs[i] = errors.New("broker unavailable")
+		}
+	}
+	return errs
+}
+
+func TestRelayProcess(t *testing.T) {
+	ok := Event{ID: uuid.New()}
+	firstFailure := Event{ID: uuid.New()}
+	thirdFailure := Event{ID: uuid.New(), Attempts: 2}
+	lastFailure := Event{ID: uuid.New(), Attempts: 4}
+
+	reader := &fakeReader{
+		events:  []Event{ok, firstFailure, thirdFailure, lastFailure},
+		retries: make(map[uuid.UUID]time.Duration),
+	}
+	publisher := &fakePublisher{fail: map[uuid.UUID]bool{
+		firstFailure.ID: true,
+		thirdFailure.ID: true,
+		lastFailure.ID:  true,
+	}}
+
+	log, err := logger.NewLogger("error")
+	if err != nil {
+		t.Fatalf("NewLogger: %v", err)
+	}
+
+	r := NewRelay(reader, publisher, log, RelayConfig{
+		BatchSize:   10,
+		MaxAttempts: 5,
+		BaseBackoff: time.Second,
+		MaxBackoff:  time.Minute,
+
+		MetricsNamespace: "relay_test",
+	})
+	r.process(context.Background())
+
+	if len(reader.published) != 1 || reader.published[0] != ok.ID {
+		t.Errorf("published = %v, want only %s", reader.published, ok.ID)
+	}
+
+	wantRetries := map[uuid.UUID]time.Duration{
+		firstFailure.ID: time.Second,
+		thirdFailure.ID: 4 * time.Second,
+	}
+	if len(reader.retries) != len(wantRetries) {
+		t.Errorf("retries = %v, want %v", reader.retries, wantRetries)
+	}
+	for id, want := range wantRetries {
+		if got, ok := reader.retries[id]; !ok || got != want {
+			t.Errorf("retry delay for %s = %v, want %v", id, got, want)
+		}
+	}
+
+	if len(reader.failed) != 1 || reader.failed[0] != lastFailure.ID {
+		t.Errorf("failed = %v, want only %s", reader.failed, lastFailure.ID)
+	}
+}
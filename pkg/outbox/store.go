@@ -0,0 +1,280 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+const (
+	_defaultTable        = "outbox"
+	_defaultArchiveTable = "outbox_archive"
+)
+
+var (
+	_ Writer = (*Store)(nil)
+	_ Reader = (*Store)(nil)
+	_ Pruner = (*Store)(nil)
+)
+
+// TxExtractor достаёт транзакцию из контекста. Позволяет Store работать
+// с TxManager любого сервиса, например txmanager.ExtractTx.
+type TxExtractor func(ctx context.Context) (*sqlx.Tx, bool)
+
+// StoreOption — функциональная опция настройки Store.
+type StoreOption func(*Store)
+
+// Table задаёт имя таблицы outbox.
+func Table(name string) StoreOption {
+	return func(s *Store) {
+		s.table = name
+	}
+}
+
+// ArchiveTable задаёт имя таблицы, в которую ArchivePublished переносит опубликованные события.
+func ArchiveTable(name string) StoreOption {
+	return func(s *Store) {
+		s.archiveTable = name
+	}
+}
+
+// Store — Postgres-хранилище outbox.
+type Store struct {
+	db           *sqlx.DB
+	extractTx    TxExtractor
+	table        string
+	archiveTable string
+}
+
+// NewStore создаёт Postgres-хранилище outbox. extractTx может быть nil, если запись никогда не идёт в транзакции.
+func NewStore(db *sqlx.DB, extractTx TxExtractor, opts ...StoreOption) *Store {
+	s := &Store{
+		db:           db,
+		extractTx:    extractTx,
+		table:        _defaultTable,
+		archiveTable: _defaultArchiveTable,
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+type eventRow struct {
+	ID        uuid.UUID       `db:"id"`
+	Topic     string          `db:"topic"`
+	EventType string          `db:"event_type"`
+	Payload   json.RawMessage `db:"payload"`
+	CreatedAt time.Time       `db:"created_at"`
+	Attempts  int             `db:"attempts"`
+}
+
+func (s *Store) Write(ctx context.Context, evts ...Event) error {
+	const op = "outbox.Store.Write"
+	query := fmt.Sprintf(`
+		INSERT INTO %s (id, topic, event_type, payload, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`, s.table)
+
+	var q sqlx.ExecerContext = s.db
+	if s.extractTx != nil {
+		if tx, ok := s.extractTx(ctx); ok {
+			q = tx
+		}
+	}
+
+	for _, evt := range evts {
+		// created_at — TIMESTAMP без зоны, его сравнивают с now() базы: время пишется в UTC
+		if _, err := q.ExecContext(ctx, query, evt.ID, evt.Topic, evt.EventType, evt.Payload, evt.CreatedAt.UTC()); err != nil {
+			return fmt.Errorf("%s: failed to insert %s event: %w", op, evt.EventType, err)
+		}
+	}
+
+	return nil
+}
+
+// ClaimPending захватывает готовые к публикации события и сдвигает next_attempt_at на lease.
+// SKIP LOCKED пропускает строки, которые в этот момент захватывает другая реплика,
+// а аренда не даёт выдать захваченные события повторно, пока Relay их публикует.
+func (s *Store) ClaimPending(ctx context.Context, limit int, lease time.Duration) ([]Event, error) {
+	const op = "outbox.Store.ClaimPending"
+	query := fmt.Sprintf(`
+		UPDATE %[1]s
+		SET next_attempt_at = now() + $2 * interval '1 millisecond'
+		WHERE id IN (
+			SELECT id
+			FROM %[1]s
+			WHERE status = 'pending' AND next_attempt_at <= now()
+			ORDER BY created_at ASC
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, topic, event_type, payload, created_at, attempts
+	`, s.table)
+
+	var rows []eventRow
+	if err := s.db.SelectContext(ctx, &rows, query, limit, lease.Milliseconds()); err != nil {
+		return nil, fmt.Errorf("%s: failed to claim events: %w", op, err)
+	}
+
+	// RETURNING не сохраняет порядок подзапроса
+	slices.SortFunc(rows, func(a, b eventRow) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+
+	evts := make([]Event, len(rows))
+	for i, row := range rows {
+		evts[i] = Event(row)
+	}
+
+	return evts, nil
+}
+
+func (s *Store) MarkPublished(ctx context.Context, ids []uuid.UUID) error {
+	const op = "outbox.Store.MarkPublished"
+	query := fmt.Sprintf(`
+		UPDATE %s
+		SET status = 'published', published_at = now(), last_error = NULL
+		WHERE id = ANY($1)
+	`, s.table)
+
+	if len(ids) == 0 {
+		return nil
+	}
+
+	if _, err := s.db.ExecContext(ctx, query, pq.Array(ids)); err != nil {
+		return fmt.Errorf("%s: failed to mark events as published: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Store) ScheduleRetry(ctx context.Context, id uuid.UUID, delay time.Duration, lastErr string) error {
+	const op = "outbox.Store.ScheduleRetry"
+	query := fmt.Sprintf(`
+		UPDATE %s
+		SET attempts = attempts + 1, next_attempt_at = now() + $2 * interval '1 millisecond', last_error = $3
+		WHERE id = $1
+	`, s.table)
+
+	if _, err := s.db.ExecContext(ctx, query, id, delay.Milliseconds(), lastErr); err != nil {
+		return fmt.Errorf("%s: failed to schedule retry: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Store) MarkFailed(ctx context.Context, id uuid.UUID, lastErr string) error {
+	const op = "outbox.Store.MarkFailed"
+	query := fmt.Sprintf(`
+		UPDATE %s
+		SET status = 'failed', attempts = attempts + 1, last_error = $2
+		WHERE id = $1
+	`, s.table)
+
+	if _, err := s.db.ExecContext(ctx, query, id, lastErr); err != nil {
+		return fmt.Errorf("%s: failed to mark event as failed: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Store) Stats(ctx context.Context) (Stats, error) {
+	const op = "outbox.Store.Stats"
+	query := fmt.Sprintf(`
+		SELECT
+			COUNT(*) FILTER (WHERE status = 'pending') AS pending,
+			COUNT(*) FILTER (WHERE status = 'failed') AS failed,
+			COALESCE(EXTRACT(EPOCH FROM now() - MIN(created_at) FILTER (WHERE status = 'pending')), 0) AS lag_seconds
+		FROM %s
+		WHERE status <> 'published'
+	`, s.table)
+
+	var row struct {
+		Pending    int     `db:"pending"`
+		Failed     int     `db:"failed"`
+		LagSeconds float64 `db:"lag_seconds"`
+	}
+	if err := s.db.GetContext(ctx, &row, query); err != nil {
+		return Stats{}, fmt.Errorf("%s: failed to get outbox stats: %w", op, err)
+	}
+
+	return Stats{
+		Pending: row.Pending,
+		Failed:  row.Failed,
+		Lag:     time.Duration(row.LagSeconds * float64(time.Second)),
+	}, nil
+}
+
+// DeletePublished удаляет до limit событий, опубликованных раньше чем olderThan назад.
+// Возвращает число удалённых событий.
+func (s *Store) DeletePublished(ctx context.Context, olderThan time.Duration, limit int) (int, error) {
+	const op = "outbox.Store.DeletePublished"
+	query := fmt.Sprintf(`
+		DELETE FROM %[1]s
+		WHERE id IN (%[2]s)
+	`, s.table, s.expiredQuery())
+
+	res, err := s.db.ExecContext(ctx, query, olderThan.Milliseconds(), limit)
+	if err != nil {
+		return 0, fmt.Errorf("%s: failed to delete published events: %w", op, err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("%s: failed to get affected rows: %w", op, err)
+	}
+
+	return int(n), nil
+}
+
+// ArchivePublished переносит до limit событий, опубликованных раньше чем olderThan назад,
+// в архивную таблицу. Возвращает число перенесённых событий.
+func (s *Store) ArchivePublished(ctx context.Context, olderThan time.Duration, limit int) (int, error) {
+	const op = "outbox.Store.ArchivePublished"
+	query := fmt.Sprintf(`
+		WITH moved AS (
+			DELETE FROM %[1]s
+			WHERE id IN (%[3]s)
+			RETURNING id, topic, event_type, payload, attempts, published_at, created_at
+		)
+		INSERT INTO %[2]s (id, topic, event_type, payload, attempts, published_at, created_at)
+		SELECT id, topic, event_type, payload, attempts, published_at, created_at
+		FROM moved
+		ON CONFLICT (id) DO NOTHING
+	`, s.table, s.archiveTable, s.expiredQuery())
+
+	// Удаление и вставка выполняются одним запросом, поэтому событие не теряется между таблицами
+	res, err := s.db.ExecContext(ctx, query, olderThan.Milliseconds(), limit)
+	if err != nil {
+		return 0, fmt.Errorf("%s: failed to archive published events: %w", op, err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("%s: failed to get affected rows: %w", op, err)
+	}
+
+	return int(n), nil
+}
+
+// expiredQuery выбирает пачку опубликованных событий старше $1 миллисекунд, не более $2 штук.
+// SKIP LOCKED позволяет нескольким репликам чистить таблицу одновременно.
+func (s *Store) expiredQuery() string {
+	return fmt.Sprintf(`
+		SELECT id
+		FROM %s
+		WHERE status = 'published' AND published_at < now() - $1 * interval '1 millisecond'
+		ORDER BY published_at ASC
+		LIMIT $2
+		FOR UPDATE SKIP LOCKED
+	`, s.table)
+}
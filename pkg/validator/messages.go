@@ -27,8 +27,14 @@ func messageForTag(tag string, param string) string {
 		return fmt.Sprintf("must be equal to %s", param)
 	case "ne":
 		return fmt.Sprintf("must not be equal to %s", param)
+	case "unique":
+		return "must not contain duplicates"
 	case "oneof":
 		return fmt.Sprintf("must be one of: %s", param)
+	case "e164":
+		return "must be a phone number in E.164 format"
+	case "iso3166_1_alpha2":
+		return "must be an ISO 3166-1 alpha-2 country code"
 	default:
 		return "invalid value"
 	}